@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// runCacheCommand handles the "cache stats" and "cache clear" sub-commands.
+func runCacheCommand(args []string) error {
+	c := internal.DefaultConfig()
+
+	fs := flag.NewFlagSet("cache", flag.ExitOnError)
+	cacheDir := fs.String("cache-dir", c.CacheDir, "directory for cached USTA pages")
+	cacheTTL := fs.String("cache-ttl", "", "comma-separated kind=duration cache TTLs to count stale pages by (kinds: organization, team, scorecard, flight, orglist)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: usta-norcal-club-newsletter cache [flags] stats|clear\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one of 'stats' or 'clear'")
+	}
+
+	ttls, err := parseCacheTTLs(*cacheTTL)
+	if err != nil {
+		return err
+	}
+	dc := usta.NewDiskCache(*cacheDir, ttls)
+
+	switch fs.Arg(0) {
+	case "stats":
+		stats, err := dc.Stats()
+		if err != nil {
+			return err
+		}
+		printCacheStats(dc, stats)
+		return nil
+	case "clear":
+		if err := dc.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("Cleared", dc.Dir())
+		return nil
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown cache command: %s (use 'stats' or 'clear')", fs.Arg(0))
+	}
+}
+
+func printCacheStats(dc *usta.DiskCache, stats usta.CacheStats) {
+	fmt.Printf("Cache directory: %s\n", stats.Dir)
+
+	var kinds []string
+	total := 0
+	for k, n := range stats.Entries {
+		kinds = append(kinds, string(k))
+		total += n
+	}
+	sort.Strings(kinds)
+
+	fmt.Printf("Pages: %d (%.1f MB)\n", total, float64(stats.Bytes)/(1<<20))
+	for _, k := range kinds {
+		kind := usta.PageKind(k)
+		fmt.Printf("  %-14s %5d pages, %5d stale (ttl %s)\n", k, stats.Entries[kind], stats.Stale[kind], dc.TTL(kind))
+	}
+	if total > 0 {
+		fmt.Printf("Oldest fetch: %s\n", stats.Oldest.Format(time.DateTime))
+		fmt.Printf("Newest fetch: %s\n", stats.Newest.Format(time.DateTime))
+	}
+}
+
+// parseCacheTTLs parses a comma-separated list of kind=duration pairs, e.g.
+// "team=30m,scorecard=168h".
+func parseCacheTTLs(s string) (map[usta.PageKind]time.Duration, error) {
+	ttls := map[usta.PageKind]time.Duration{}
+	if s == "" {
+		return ttls, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		kind, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid cache TTL %q: expected kind=duration", pair)
+		}
+		if _, known := usta.DefaultPageTTLs[usta.PageKind(kind)]; !known {
+			return nil, fmt.Errorf("invalid cache TTL %q: unknown page kind %q", pair, kind)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache TTL %q: %w", pair, err)
+		}
+		ttls[usta.PageKind(kind)] = d
+	}
+
+	return ttls, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// runDiagnoseTeamCommand handles the "diagnose-team <id>" sub-command, which
+// prints every row of a team's schedule table, how it was parsed and why any
+// row was skipped.
+func runDiagnoseTeamCommand(args []string) error {
+	c := internal.DefaultConfig()
+
+	fs := flag.NewFlagSet("diagnose-team", flag.ExitOnError)
+	cacheDir := fs.String("cache-dir", c.CacheDir, "directory for cached USTA pages")
+	noCache := fs.Bool("no-cache", false, "disable the on-disk page cache")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: usta-norcal-club-newsletter diagnose-team [flags] <team-id>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected a team ID")
+	}
+	id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid team ID %q: %w", fs.Arg(0), err)
+	}
+
+	if !*noCache {
+		usta.SetDiskCache(usta.NewDiskCache(*cacheDir, c.CacheTTLs))
+	}
+
+	t, err := usta.LoadTeam(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("loading team %d: %w", id, err)
+	}
+
+	fmt.Printf("Team %d: %s %s\n", t.ID, t.Name, t.Code)
+	fmt.Printf("Flight: %s (ID %d)\n\n", t.FlightName, t.FlightID)
+
+	rows, err := t.ScheduleRows()
+	if err != nil {
+		return err
+	}
+
+	parsed, skipped := 0, 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(w, "Row %d:\t%s\n", row.Index, strings.Join(row.Cells, " | "))
+		switch {
+		case errors.Is(row.Err, usta.ErrNotMatchRow):
+			skipped++
+			fmt.Fprintf(w, "\tskipped: not a match\n")
+		case row.Err != nil:
+			skipped++
+			fmt.Fprintf(w, "\tskipped: %s\n", row.Err)
+		default:
+			parsed++
+			e := row.Entry
+			location := "away"
+			if e.Home {
+				location = "home"
+			}
+			when := e.Date.Format("Mon 2006-01-02")
+			if e.HasTime {
+				when = e.Date.Format("Mon 2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "\tmatch %d (%s) %s, %s vs. team %d %q\n", e.Number, e.Type, when, location, e.OpponentID, e.OpponentName)
+			if e.OutcomeKind != usta.OutcomeUnknown {
+				fmt.Fprintf(w, "\toutcome: %s %s %d-%d, scorecard %d\n", e.OutcomeKind, e.OutcomeVerb, e.WinnerPoints, e.LoserPoints, e.ScorecardID)
+			}
+			if len(e.Schedule.Starts) > 1 || len(e.Schedule.Courts) > 0 || e.Schedule.Note != "" {
+				fmt.Fprintf(w, "\tschedule: %d start time(s), courts %v, note %q\n", len(e.Schedule.Starts), e.Schedule.Courts, e.Schedule.Note)
+			}
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d row(s): %d parsed, %d skipped\n", len(rows), parsed, skipped)
+	return nil
+}
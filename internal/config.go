@@ -1,33 +1,70 @@
 package internal
 
 import (
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/ycombinator/usta-norcal-club-newsletter/internal/formatters"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
 )
 
 const asrcOrganizationID = 225
 
+// Layout decides how a run covering several organizations is laid out.
+type Layout string
+
+const (
+	// LayoutSeparate writes a separate set of files for each organization.
+	LayoutSeparate Layout = "separate"
+	// LayoutCombined writes one newsletter with a section per organization.
+	LayoutCombined Layout = "combined"
+)
+
 // Config holds the application configuration.
 type Config struct {
-	OrganizationID int
-	TeamIDs        []int
+	// OrganizationIDs are the clubs to generate newsletters for; Layout
+	// decides whether they get one combined newsletter or one each.
+	OrganizationIDs []int
+	Layout          Layout
+	TeamIDs         []int
 
 	PastDuration   time.Duration
 	FutureDuration time.Duration
 
+	// TimeZone is the league's IANA time zone, which match times are read
+	// and windowed in; DisplayTimeZone, if set, is the zone they're shown in.
+	TimeZone        string
+	DisplayTimeZone string
+
 	RecentFormatter   formatters.RecentFormatter
 	UpcomingFormatter formatters.UpcomingFormatter
+
+	// CacheDir is where fetched USTA pages are cached between runs; empty
+	// disables the on-disk cache.
+	CacheDir  string
+	CacheTTLs map[usta.PageKind]time.Duration
+
+	Fetcher usta.FetcherConfig
+
+	// StateFile is where the last-seen date of each match is kept between
+	// runs to spot rescheduled matches; empty disables tracking.
+	StateFile string
 }
 
 // DefaultConfig returns the default application configuration.
 func DefaultConfig() Config {
 	f := formatters.NewJPEGFormatter()
 	return Config{
-		OrganizationID:    asrcOrganizationID,
+		OrganizationIDs:   []int{asrcOrganizationID},
+		Layout:            LayoutSeparate,
 		PastDuration:      7 * 24 * time.Hour,
+		TimeZone:          "America/Los_Angeles",
 		FutureDuration:    7 * 24 * time.Hour,
 		RecentFormatter:   f,
 		UpcomingFormatter: f,
+		CacheDir:          filepath.Join(os.Getenv("HOME"), ".usta-norcal", "cache"),
+		Fetcher:           usta.DefaultFetcherConfig,
+		StateFile:         filepath.Join(os.Getenv("HOME"), ".usta-norcal", "schedule.json"),
 	}
 }
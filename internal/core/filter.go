@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// TeamRule matches teams by what their names and codes say about them. A
+// team matches if it has one of the listed values for every attribute the
+// rule sets, so an empty rule matches every team.
+type TeamRule struct {
+	// Gender is "womens", "mens" or "mixed"; "women", "ladies" and "men"
+	// work too.
+	Gender values `yaml:"gender"`
+	// Level is e.g. "3.5" or "4.0+"; a Tri-Level team matches each of its
+	// levels.
+	Level values `yaml:"level"`
+	// Age is the age division, e.g. "55+" or "14U".
+	Age values `yaml:"age"`
+	// Daytime, if set, matches only daytime teams, or only evening teams.
+	Daytime *bool `yaml:"daytime"`
+	// League is the league type, e.g. "adult", "mixed", "combo",
+	// "tri-level", "daytime", "junior" or "flex".
+	League values `yaml:"league"`
+	// Teams lists team IDs.
+	Teams []int `yaml:"teams"`
+}
+
+// TeamFilter selects the teams a newsletter covers: those matching any
+// Include rule, or every team if there are none, apart from those matching
+// any Exclude rule.
+type TeamFilter struct {
+	Include []TeamRule `yaml:"include"`
+	Exclude []TeamRule `yaml:"exclude"`
+}
+
+// DefaultTeamGroups are the named team filters available without a groups
+// file.
+var DefaultTeamGroups = map[string]TeamFilter{
+	"seniors": {
+		Include: []TeamRule{{Age: values{"55+", "65+"}}},
+	},
+	"daytime-ladies": {
+		Include: []TeamRule{{Gender: values{"womens"}, Daytime: new(true)}},
+	},
+}
+
+// Matches reports whether the filter covers t.
+func (f TeamFilter) Matches(t *usta.Team) bool {
+	d := t.Display()
+	if len(f.Include) > 0 && !slices.ContainsFunc(f.Include, func(r TeamRule) bool { return r.matches(t.ID, d) }) {
+		return false
+	}
+	return !slices.ContainsFunc(f.Exclude, func(r TeamRule) bool { return r.matches(t.ID, d) })
+}
+
+func (r TeamRule) matches(id int, d usta.TeamDisplay) bool {
+	if len(r.Gender) > 0 && !slices.ContainsFunc(r.Gender, func(g string) bool { return usta.ParseGender(g) == d.Gender }) {
+		return false
+	}
+	if len(r.Level) > 0 && !slices.ContainsFunc(strings.Split(d.Level, "/"), r.Level.contains) {
+		return false
+	}
+	if len(r.Age) > 0 && !slices.ContainsFunc(r.Age, func(a string) bool { return sameAge(a, d.AgeDivision) }) {
+		return false
+	}
+	if r.Daytime != nil && *r.Daytime != d.Daytime {
+		return false
+	}
+	if len(r.League) > 0 && !r.League.contains(string(d.Family)) {
+		return false
+	}
+	if len(r.Teams) > 0 && !slices.Contains(r.Teams, id) {
+		return false
+	}
+	return true
+}
+
+func (r TeamRule) validate() error {
+	for _, g := range r.Gender {
+		if usta.ParseGender(g) == usta.GenderUnknown {
+			return fmt.Errorf("unknown gender %q (use womens, mens or mixed)", g)
+		}
+	}
+	for _, l := range r.League {
+		if !slices.ContainsFunc(leagues, func(known usta.League) bool { return strings.EqualFold(l, string(known)) }) {
+			return fmt.Errorf("unknown league %q (use adult, mixed, combo, tri-level, daytime, junior or flex)", l)
+		}
+	}
+	return nil
+}
+
+var leagues = []usta.League{
+	usta.LeagueAdult,
+	usta.LeagueMixed,
+	usta.LeagueCombo,
+	usta.LeagueTriLevel,
+	usta.LeagueDaytime,
+	usta.LeagueJunior,
+	usta.LeagueFlex,
+}
+
+func (f TeamFilter) validate() error {
+	for i, r := range f.Include {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("include rule %d: %w", i+1, err)
+		}
+	}
+	for i, r := range f.Exclude {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("exclude rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// LoadTeamGroups reads named team filters from the YAML file at path, adding
+// to or replacing DefaultTeamGroups. A missing file yields
+// DefaultTeamGroups.
+func LoadTeamGroups(path string) (map[string]TeamFilter, error) {
+	groups := make(map[string]TeamFilter, len(DefaultTeamGroups))
+	for name, f := range DefaultTeamGroups {
+		groups[name] = f
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return groups, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var file map[string]TeamFilter
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for name, f := range file {
+		if err := f.validate(); err != nil {
+			return nil, fmt.Errorf("%s: group %q: %w", path, name, err)
+		}
+		groups[name] = f
+	}
+	return groups, nil
+}
+
+// values is a list of attribute values, which a YAML file may also give as a
+// single value.
+type values []string
+
+func (v *values) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*v = values{n.Value}
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return err
+	}
+	*v = list
+	return nil
+}
+
+// sameAge reports whether a and b are the same age division, with or without
+// the "+", e.g. "55" and "55+".
+func sameAge(a, b string) bool {
+	return b != "" && strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(a), "+"), strings.TrimSuffix(b, "+"))
+}
+
+// contains reports whether v lists s, ignoring case.
+func (v values) contains(s string) bool {
+	return slices.ContainsFunc(v, func(x string) bool { return strings.EqualFold(strings.TrimSpace(x), s) })
+}
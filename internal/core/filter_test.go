@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestTeamFilterMatches(t *testing.T) {
+	womens55 := &usta.Team{ID: 1, Name: "2026 Adult 55 & Over Womens 3.5"}
+	mens65 := &usta.Team{ID: 2, Name: "2026 Adult 65 & Over Mens 3.0"}
+	daytime := &usta.Team{ID: 3, Name: "2026 Adult 18+ Womens 3.5 Daytime"}
+	mixed := &usta.Team{ID: 4, Name: "2026 Mixed 40 & Over 7.0"}
+	mens18 := &usta.Team{ID: 5, Name: "2026 Adult 18+ Mens 4.0"}
+	all := []*usta.Team{womens55, mens65, daytime, mixed, mens18}
+
+	tests := map[string]struct {
+		filter TeamFilter
+		want   []*usta.Team
+	}{
+		"empty": {
+			filter: TeamFilter{},
+			want:   all,
+		},
+		"seniors": {
+			filter: DefaultTeamGroups["seniors"],
+			want:   []*usta.Team{womens55, mens65},
+		},
+		"daytime_ladies": {
+			filter: DefaultTeamGroups["daytime-ladies"],
+			want:   []*usta.Team{daytime},
+		},
+		"age_without_plus": {
+			filter: TeamFilter{Include: []TeamRule{{Age: values{"40"}}}},
+			want:   []*usta.Team{mixed},
+		},
+		"level": {
+			filter: TeamFilter{Include: []TeamRule{{Level: values{"3.5"}}}},
+			want:   []*usta.Team{womens55, daytime},
+		},
+		"gender_alias": {
+			filter: TeamFilter{Include: []TeamRule{{Gender: values{"men"}}}},
+			want:   []*usta.Team{mens65, mens18},
+		},
+		"league": {
+			filter: TeamFilter{Include: []TeamRule{{League: values{"Mixed"}}}},
+			want:   []*usta.Team{mixed},
+		},
+		"evening": {
+			filter: TeamFilter{Include: []TeamRule{{Gender: values{"womens"}, Daytime: new(false)}}},
+			want:   []*usta.Team{womens55},
+		},
+		"any_include_rule": {
+			filter: TeamFilter{Include: []TeamRule{{Teams: []int{5}}, {League: values{"mixed"}}}},
+			want:   []*usta.Team{mixed, mens18},
+		},
+		"exclude": {
+			filter: TeamFilter{Exclude: []TeamRule{{Gender: values{"mens"}}, {Teams: []int{3}}}},
+			want:   []*usta.Team{womens55, mixed},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []*usta.Team
+			for _, team := range all {
+				if test.filter.Matches(team) {
+					got = append(got, team)
+				}
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestTeamFilterMatchesTriLevel(t *testing.T) {
+	team := &usta.Team{Name: "2026 Tri-Level 18 & Over Womens 3.0/3.5/4.0", Code: "CLUB SR 18TW3.5A"}
+	require.Equal(t, "3.0/3.5/4.0", team.Display().Level)
+	require.True(t, TeamFilter{Include: []TeamRule{{Level: values{"4.0"}}}}.Matches(team))
+	require.False(t, TeamFilter{Include: []TeamRule{{Level: values{"4.5"}}}}.Matches(team))
+}
+
+func TestLoadTeamGroups(t *testing.T) {
+	groups, err := LoadTeamGroups(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultTeamGroups, groups)
+
+	path := filepath.Join(t.TempDir(), "groups.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+seniors:
+  include:
+    - age: 65+
+evening-mixed:
+  include:
+    - league: [mixed]
+      daytime: false
+  exclude:
+    - teams: [98765]
+`), 0644))
+	groups, err = LoadTeamGroups(path)
+	require.NoError(t, err)
+	require.Equal(t, TeamFilter{Include: []TeamRule{{Age: values{"65+"}}}}, groups["seniors"])
+	require.Equal(t, TeamFilter{
+		Include: []TeamRule{{League: values{"mixed"}, Daytime: new(false)}},
+		Exclude: []TeamRule{{Teams: []int{98765}}},
+	}, groups["evening-mixed"])
+	require.Contains(t, groups, "daytime-ladies")
+
+	require.NoError(t, os.WriteFile(path, []byte("ladies:\n  include:\n    - gender: woman\n"), 0644))
+	_, err = LoadTeamGroups(path)
+	require.ErrorContains(t, err, `unknown gender "woman"`)
+}
+
+func TestGenerateFiltersTeams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+	p, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	n, err := NewNewsletter([]int{1}, nil)
+	require.NoError(t, err)
+	n.SetProvider(p)
+	n.SetFilter(TeamFilter{Exclude: []TeamRule{{Gender: values{"womens"}}}})
+
+	require.NoError(t, n.Generate(context.Background()))
+	require.Empty(t, n.Snapshot().Organization().Teams)
+
+	// The provider's own organization is left as it was.
+	o, err := p.LoadOrganization(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, o.Teams, 1)
+}
+
+func TestGenerateKeepsExtraTeamsOutOfProviderOrganization(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+	p, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	for range 2 {
+		n, err := NewNewsletter([]int{1}, []int{20})
+		require.NoError(t, err)
+		n.SetProvider(p)
+		require.NoError(t, n.Generate(context.Background()))
+		require.Len(t, n.Snapshot().Organization().Teams, 2)
+	}
+
+	o, err := p.LoadOrganization(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, o.Teams, 1)
+}
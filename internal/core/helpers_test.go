@@ -0,0 +1,24 @@
+package core
+
+import (
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// day returns 6pm on day d of April 2026.
+func day(d int) time.Time {
+	return time.Date(2026, 4, d, 18, 0, 0, 0, time.UTC)
+}
+
+// playedMatch returns a regular-season match on day d, played out and won
+// by winner.
+func playedMatch(d int, home, visiting, winner *usta.Team, winnerPoints, loserPoints int) usta.Match {
+	return usta.Match{
+		Date:         day(d),
+		Type:         usta.MatchTypeRegular,
+		HomeTeam:     home,
+		VisitingTeam: visiting,
+		Outcome:      usta.Outcome{Kind: usta.OutcomePlayed, WinningTeam: winner, WinnerPoints: winnerPoints, LoserPoints: loserPoints},
+	}
+}
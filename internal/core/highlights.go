@@ -0,0 +1,238 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// HighlightKind is the kind of event a highlight is about.
+type HighlightKind string
+
+const (
+	// HighlightSectionals is a team with a Sectionals match coming up or
+	// just played.
+	HighlightSectionals HighlightKind = "sectionals"
+	// HighlightPlayoffs is a team with a playoff match coming up or just
+	// played.
+	HighlightPlayoffs HighlightKind = "playoffs"
+	// HighlightClinched is a team that can no longer be caught for first
+	// place in its flight.
+	HighlightClinched HighlightKind = "clinched"
+	// HighlightRevenge is a win over an opponent that beat the team earlier
+	// in the season.
+	HighlightRevenge HighlightKind = "revenge"
+	// HighlightSweep is a win on court without losing a line, e.g. 5-0.
+	HighlightSweep HighlightKind = "sweep"
+	// HighlightFirstWin is a team's first win of the season.
+	HighlightFirstWin HighlightKind = "first-win"
+	// HighlightWinStreak is a run of wins.
+	HighlightWinStreak HighlightKind = "win-streak"
+	// HighlightLossStreak is a run of losses.
+	HighlightLossStreak HighlightKind = "loss-streak"
+)
+
+// minStreak is the shortest run of wins or losses worth a highlight.
+const minStreak = 3
+
+// sweepMinPoints is the fewest lines a clean sweep must win, so a 1-0
+// default isn't counted as one.
+const sweepMinPoints = 3
+
+// Highlight is a notable event for one of a club's teams.
+type Highlight struct {
+	Kind HighlightKind
+	Team *usta.Team
+	// Match is the match the highlight is about, or the latest one for a
+	// streak or a clinched first place.
+	Match usta.Match
+	// Opponent is the team played in Match.
+	Opponent *usta.Team
+	// Count is the length of a streak.
+	Count int
+	// Rank orders highlights, the most notable first.
+	Rank int
+}
+
+// Highlights picks out the notable events for org's teams in the past
+// duration before boundary, the most notable first. boundary is as for
+// Organization.Matches. Teams' matches and flights must be loaded, as they
+// are in a snapshot made by Newsletter.Generate.
+func Highlights(org *usta.Organization, past time.Duration, boundary time.Time) []Highlight {
+	boundary = org.Boundary(boundary)
+	since := boundary.Add(-past)
+
+	var highlights []Highlight
+	for _, t := range org.Teams {
+		highlights = append(highlights, teamHighlights(t, since, boundary)...)
+	}
+
+	sort.SliceStable(highlights, func(i, j int) bool {
+		a, b := highlights[i], highlights[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		return a.Match.Date.After(b.Match.Date)
+	})
+	return highlights
+}
+
+func teamHighlights(t *usta.Team, since, boundary time.Time) []Highlight {
+	var highlights []Highlight
+	add := func(kind HighlightKind, m usta.Match, count int) {
+		highlights = append(highlights, Highlight{
+			Kind:     kind,
+			Team:     t,
+			Match:    m,
+			Opponent: opponent(t, m),
+			Count:    count,
+			Rank:     highlightRank(kind, count),
+		})
+	}
+
+	if m, ok := postseasonMatch(t, usta.MatchTypeSectionals, since); ok {
+		add(HighlightSectionals, m, 0)
+	} else if m, ok := postseasonMatch(t, usta.MatchTypePlayoff, since); ok {
+		add(HighlightPlayoffs, m, 0)
+	}
+
+	decided := decidedMatches(t, boundary)
+	var recent []usta.Match
+	for _, m := range decided {
+		if !m.Date.Before(since) {
+			recent = append(recent, m)
+		}
+	}
+	if len(recent) == 0 {
+		return highlights
+	}
+	latest := recent[len(recent)-1]
+
+	wins := 0
+	beatenBy := map[int]bool{}
+	for _, m := range decided {
+		won := m.Outcome.WinningTeam.ID == t.ID
+		opp := opponent(t, m).ID
+		if !m.Date.Before(since) && won {
+			switch {
+			case wins == 0:
+				add(HighlightFirstWin, m, 0)
+			case beatenBy[opp]:
+				add(HighlightRevenge, m, 0)
+			}
+			if m.Outcome.Kind == usta.OutcomePlayed && m.Outcome.LoserPoints == 0 && m.Outcome.WinnerPoints >= sweepMinPoints {
+				add(HighlightSweep, m, 0)
+			}
+		}
+		// A loss is only avenged by the next win over the same opponent.
+		if won {
+			wins++
+			delete(beatenBy, opp)
+		} else {
+			beatenBy[opp] = true
+		}
+	}
+
+	// The standings are only known as they are now, so a clinched first
+	// place is credited to the team's latest win, and only in the week it
+	// was clinched: when taking back this week's results undoes it.
+	if latest.Outcome.WinningTeam.ID == t.ID && clinchedFirst(t, nil) && !clinchedFirst(t, recent) {
+		add(HighlightClinched, latest, 0)
+	}
+
+	switch streak := teamSeason(t, boundary).Streak; {
+	case streak >= minStreak:
+		add(HighlightWinStreak, latest, streak)
+	case streak <= -minStreak:
+		add(HighlightLossStreak, latest, -streak)
+	}
+
+	return highlights
+}
+
+// highlightRank returns how notable a highlight of kind is; longer streaks
+// rank higher.
+func highlightRank(kind HighlightKind, count int) int {
+	switch kind {
+	case HighlightSectionals:
+		return 100
+	case HighlightClinched:
+		return 90
+	case HighlightPlayoffs:
+		return 80
+	case HighlightRevenge:
+		return 70
+	case HighlightSweep:
+		return 60
+	case HighlightFirstWin:
+		return 50
+	case HighlightWinStreak:
+		return 40 + count
+	default:
+		return 10
+	}
+}
+
+// postseasonMatch returns t's first match of type mt on or after since,
+// whether or not it has been played yet.
+func postseasonMatch(t *usta.Team, mt usta.MatchType, since time.Time) (usta.Match, bool) {
+	var first usta.Match
+	found := false
+	for _, m := range t.Matches {
+		if m.Type != mt || m.Date.Before(since) {
+			continue
+		}
+		if !found || m.Date.Before(first.Date) {
+			first, found = m, true
+		}
+	}
+	return first, found
+}
+
+// clinchedFirst reports whether t leads its flight by more than any other
+// team could still make up, in the standings with the results of undone
+// regular-season matches taken back.
+func clinchedFirst(t *usta.Team, undone []usta.Match) bool {
+	if t.Flight == nil || len(t.Flight.Standings) < 2 {
+		return false
+	}
+	wins := make(map[int]int, len(t.Flight.Standings))
+	remaining := make(map[int]int, len(t.Flight.Standings))
+	for _, s := range t.Flight.Standings {
+		wins[s.TeamID] = s.Wins
+		remaining[s.TeamID] = s.Remaining
+	}
+	if _, ok := wins[t.ID]; !ok {
+		return false
+	}
+
+	for _, m := range undone {
+		if m.Type == usta.MatchTypePlayoff || m.Type == usta.MatchTypeSectionals {
+			continue
+		}
+		if _, ok := wins[m.Outcome.WinningTeam.ID]; ok {
+			wins[m.Outcome.WinningTeam.ID]--
+		}
+		for _, team := range []*usta.Team{m.HomeTeam, m.VisitingTeam} {
+			if _, ok := remaining[team.ID]; ok {
+				remaining[team.ID]++
+			}
+		}
+	}
+
+	for id := range wins {
+		if id != t.ID && wins[id]+remaining[id] >= wins[t.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// opponent returns the team t played in m.
+func opponent(t *usta.Team, m usta.Match) *usta.Team {
+	if m.HomeTeam.ID == t.ID {
+		return m.VisitingTeam
+	}
+	return m.HomeTeam
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestHighlights(t *testing.T) {
+	rival := &usta.Team{ID: 100}
+	other := &usta.Team{ID: 101}
+
+	// Beat the team that beat them earlier, 5-0, for a third straight win
+	// that clinched first place.
+	leaders := &usta.Team{ID: 1}
+	leaders.Matches = []usta.Match{
+		playedMatch(1, leaders, rival, rival, 3, 2),
+		playedMatch(8, leaders, other, leaders, 3, 2),
+		playedMatch(15, leaders, other, leaders, 4, 1),
+		playedMatch(22, leaders, rival, leaders, 5, 0),
+		{Date: day(29), Type: usta.MatchTypePlayoff, HomeTeam: leaders, VisitingTeam: other},
+	}
+	leaders.Flight = &usta.Flight{Standings: []usta.Standing{
+		{Position: 1, TeamID: 1, Wins: 3, Losses: 1},
+		{Position: 2, TeamID: 100, Wins: 1, Losses: 1, Remaining: 1},
+	}}
+
+	// A first win after three losses.
+	strugglers := &usta.Team{ID: 2}
+	strugglers.Matches = []usta.Match{
+		playedMatch(2, strugglers, other, other, 3, 2),
+		playedMatch(9, strugglers, other, other, 3, 2),
+		playedMatch(16, strugglers, other, other, 3, 2),
+		playedMatch(21, strugglers, other, strugglers, 3, 2),
+	}
+
+	// Three straight losses, the last of them this week.
+	slumping := &usta.Team{ID: 3}
+	slumping.Matches = []usta.Match{
+		playedMatch(7, slumping, other, other, 3, 2),
+		playedMatch(14, slumping, other, other, 3, 2),
+		playedMatch(21, slumping, other, other, 3, 2),
+	}
+
+	// A 5-0 win by default isn't a sweep.
+	defaulters := &usta.Team{ID: 5}
+	defaulted := playedMatch(20, defaulters, other, defaulters, 5, 0)
+	defaulted.Outcome.Kind = usta.OutcomeDefault
+	defaulters.Matches = []usta.Match{playedMatch(6, defaulters, other, defaulters, 3, 2), defaulted}
+
+	// Nothing played this week.
+	idle := &usta.Team{ID: 4}
+	idle.Matches = []usta.Match{playedMatch(1, idle, other, idle, 5, 0)}
+
+	org := &usta.Organization{Teams: []*usta.Team{leaders, strugglers, slumping, idle, defaulters}}
+	highlights := Highlights(org, 7*24*time.Hour, time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC))
+
+	type summary struct {
+		kind  HighlightKind
+		team  int
+		count int
+	}
+	var got []summary
+	for _, h := range highlights {
+		got = append(got, summary{h.Kind, h.Team.ID, h.Count})
+	}
+	require.Equal(t, []summary{
+		{HighlightClinched, 1, 0},
+		{HighlightPlayoffs, 1, 0},
+		{HighlightRevenge, 1, 0},
+		{HighlightSweep, 1, 0},
+		{HighlightFirstWin, 2, 0},
+		{HighlightWinStreak, 1, 3},
+		{HighlightLossStreak, 3, 3},
+	}, got)
+	require.Equal(t, rival, highlights[2].Opponent)
+	require.Equal(t, day(29), highlights[1].Match.Date)
+}
+
+func TestClinchedFirst(t *testing.T) {
+	team := &usta.Team{ID: 1, Flight: &usta.Flight{Standings: []usta.Standing{
+		{TeamID: 1, Wins: 4},
+		{TeamID: 2, Wins: 2, Remaining: 2},
+	}}}
+	require.False(t, clinchedFirst(team, nil))
+
+	team.Flight.Standings[1].Remaining = 1
+	require.True(t, clinchedFirst(team, nil))
+
+	// Without this week's win over the runners-up, they could still catch up.
+	thisWeek := usta.Match{
+		Type:         usta.MatchTypeRegular,
+		HomeTeam:     team,
+		VisitingTeam: &usta.Team{ID: 2},
+		Outcome:      usta.Outcome{Kind: usta.OutcomePlayed, WinningTeam: team},
+	}
+	require.False(t, clinchedFirst(team, []usta.Match{thisWeek}))
+
+	// A lead of two more wins was clinched before this week.
+	team.Flight.Standings[0].Wins = 6
+	require.True(t, clinchedFirst(team, []usta.Match{thisWeek}))
+
+	require.False(t, clinchedFirst(&usta.Team{ID: 1}, nil))
+}
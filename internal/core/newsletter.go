@@ -4,59 +4,125 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 
 	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
 )
 
 type Newsletter struct {
-	orgID   int
-	teamIDs []int
-	org     *usta.Organization
+	orgIDs   []int
+	teamIDs  []int
+	strict   bool
+	progress ProgressFunc
+	filter   *TeamFilter
+	provider usta.Provider
+	snapshot *Snapshot
+	report   *usta.LoadReport
 }
 
-func NewNewsletter(orgID int, teamIDs []int) (*Newsletter, error) {
+// NewNewsletter returns a newsletter for one or more organizations. Extra
+// teams are tracked as part of the first organization.
+func NewNewsletter(orgIDs []int, teamIDs []int) (*Newsletter, error) {
+	if len(orgIDs) == 0 {
+		return nil, fmt.Errorf("no organization given")
+	}
+
 	n := new(Newsletter)
-	n.orgID = orgID
+	n.orgIDs = orgIDs
 	n.teamIDs = teamIDs
+	n.provider = usta.NewNorCalProvider()
+	n.snapshot = &Snapshot{orgIDs: orgIDs}
 
 	return n, nil
 }
 
-func (n Newsletter) Organization() *usta.Organization {
-	return n.org
+// SetProvider sets where league data is loaded from. The default is the
+// USTA NorCal site.
+func (n *Newsletter) SetProvider(p usta.Provider) {
+	n.provider = p
+}
+
+// SetStrict controls whether Generate fails when any team, opponent or page
+// fails to load. In lenient mode (the default) failures are only recorded in
+// the load report.
+func (n *Newsletter) SetStrict(strict bool) {
+	n.strict = strict
+}
+
+// SetFilter limits the newsletter to the teams f matches, out of the
+// organizations' teams and the extra teams.
+func (n *Newsletter) SetFilter(f TeamFilter) {
+	n.filter = &f
+}
+
+// SetProgress sets a function to receive progress reports while Generate
+// runs.
+func (n *Newsletter) SetProgress(fn ProgressFunc) {
+	n.progress = fn
+}
+
+// Snapshot returns the league data made by the last call to Generate. Until
+// Generate succeeds it holds only the organization IDs.
+func (n Newsletter) Snapshot() *Snapshot {
+	return n.snapshot
+}
+
+// Report returns the failures recorded by the last call to Generate.
+func (n Newsletter) Report() *usta.LoadReport {
+	return n.report
 }
 
 func (n *Newsletter) Generate(ctx context.Context) error {
-	slog.Info("loading organization", "org_id", n.orgID)
-	org, err := usta.LoadOrganization(ctx, n.orgID)
-	if err != nil {
-		return err
+	n.report = usta.NewLoadReport()
+	ctx = usta.WithLoadReport(ctx, n.report)
+	progress := newProgressTracker(n.progress, n.report)
+	ctx = usta.WithFetchStats(ctx, progress.stats)
+
+	// Organizations load concurrently; opponents they share are fetched
+	// once thanks to the shared page caches.
+	slog.Info("loading organizations", "org_ids", n.orgIDs)
+	progress.start(PhaseOrganizations, len(n.orgIDs))
+	orgs := make([]*usta.Organization, len(n.orgIDs))
+	errs := make([]error, len(n.orgIDs))
+	var orgWG sync.WaitGroup
+	for i, id := range n.orgIDs {
+		orgWG.Add(1)
+		go func(i, id int) {
+			defer orgWG.Done()
+			orgs[i], errs[i] = n.provider.LoadOrganization(ctx, id)
+			progress.teamDone()
+		}(i, id)
+	}
+	orgWG.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("loading organization %d: %w", n.orgIDs[i], err)
+		}
+		slog.Info("loaded organization", "org_id", n.orgIDs[i], "name", orgs[i].Name, "teams", len(orgs[i].Teams))
 	}
-	n.org = org
-	slog.Info("loaded organization", "org_id", n.orgID, "name", org.Name)
 
-	slog.Info("loading teams for organization")
-	if _, err = n.org.LoadTeams(ctx); err != nil {
-		return err
+	// The provider may cache its organizations, so the teams to cover are
+	// gathered separately and only set on the snapshot's copies.
+	orgTeams := make([][]*usta.Team, len(orgs))
+	for i, o := range orgs {
+		orgTeams[i] = slices.Clone(o.Teams)
 	}
-	slog.Info("loaded teams", "count", len(n.org.Teams))
 
 	if len(n.teamIDs) > 0 {
 		slog.Info("loading extra teams", "team_ids", n.teamIDs)
+		progress.start(PhaseExtraTeams, len(n.teamIDs))
 		var wg sync.WaitGroup
 		var mu sync.Mutex
 		var extraTeams []*usta.Team
-		var errs []error
 		for _, id := range n.teamIDs {
 			wg.Add(1)
 			go func(id int) {
 				defer wg.Done()
-				t, err := usta.LoadTeam(ctx, id)
+				defer progress.teamDone()
+				t, err := n.provider.LoadTeam(ctx, id)
 				if err != nil {
-					mu.Lock()
-					errs = append(errs, err)
-					mu.Unlock()
+					usta.ReportFailure(ctx, usta.LoadFailure{What: "extra team", ID: id, Err: err})
 					return
 				}
 				t.Extra = true
@@ -69,25 +135,41 @@ func (n *Newsletter) Generate(ctx context.Context) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if len(errs) > 0 {
-			return fmt.Errorf("failed to load %d extra team(s)", len(errs))
-		}
-		n.org.Teams = append(n.org.Teams, extraTeams...)
+		orgTeams[0] = append(orgTeams[0], extraTeams...)
 		slog.Info("loaded extra teams", "count", len(extraTeams))
 	}
 
-	slog.Info("loading matches for all teams", "team_count", len(n.org.Teams))
+	if n.filter != nil {
+		for i, o := range orgs {
+			total := len(orgTeams[i])
+			orgTeams[i] = slices.DeleteFunc(orgTeams[i], func(t *usta.Team) bool { return !n.filter.Matches(t) })
+			slog.Info("filtered teams", "org_id", o.ID, "kept", len(orgTeams[i]), "total", total)
+		}
+	}
+
+	var teams []*usta.Team
+	for _, ts := range orgTeams {
+		teams = append(teams, ts...)
+	}
+
+	slog.Info("loading matches for all teams", "team_count", len(teams))
+	progress.start(PhaseMatches, len(teams))
 	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var errs []error
-	for _, t := range n.org.Teams {
+	for _, t := range teams {
 		wg.Add(1)
 		go func(t *usta.Team) {
 			defer wg.Done()
-			if _, err := t.LoadMatches(ctx); err != nil {
-				mu.Lock()
-				errs = append(errs, err)
-				mu.Unlock()
+			defer progress.teamDone()
+			if err := n.provider.LoadMatches(ctx, t); err != nil {
+				usta.ReportFailure(ctx, usta.LoadFailure{What: "matches", ID: t.ID, Err: err})
+				return
+			}
+			usta.ObserveSchedule(t)
+			if err := n.provider.LoadRoster(ctx, t); err != nil {
+				usta.ReportFailure(ctx, usta.LoadFailure{What: "roster", ID: t.ID, Err: err})
+			}
+			if err := n.provider.LoadFlight(ctx, t); err != nil {
+				usta.ReportFailure(ctx, usta.LoadFailure{What: "flight standings", TeamID: t.ID, Err: err})
 			}
 		}(t)
 	}
@@ -96,15 +178,73 @@ func (n *Newsletter) Generate(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to load matches for %d team(s)", len(errs))
-	}
 
 	totalMatches := 0
-	for _, t := range n.org.Teams {
+	for _, t := range teams {
 		totalMatches += len(t.Matches)
 	}
 	slog.Info("loaded all matches", "total_matches", totalMatches)
 
+	if err := n.resolveOrganizations(ctx, teams, progress); err != nil {
+		return err
+	}
+
+	if n.report.HasFailures() {
+		if n.strict {
+			return n.report.Err()
+		}
+		slog.Warn("some pages failed to load; newsletter may be incomplete", "failures", len(n.report.Failures()))
+	}
+
+	snapOrgs := make([]*usta.Organization, len(orgs))
+	for i, o := range orgs {
+		c := *o
+		c.Teams = orgTeams[i]
+		snapOrgs[i] = &c
+	}
+
+	n.snapshot = &Snapshot{
+		orgIDs:   n.orgIDs,
+		orgs:     snapOrgs,
+		failures: n.report.Failures(),
+	}
+	progress.start(PhaseDone, 0)
 	return nil
 }
+
+// resolveOrganizations loads the organization, with its address, of every
+// team playing in the teams' matches, so formatting never has to.
+func (n *Newsletter) resolveOrganizations(ctx context.Context, teams []*usta.Team, progress *progressTracker) error {
+	seen := make(map[*usta.Team]bool)
+	var pending []*usta.Team
+	add := func(t *usta.Team) {
+		if t != nil && !seen[t] {
+			seen[t] = true
+			pending = append(pending, t)
+		}
+	}
+	for _, t := range teams {
+		add(t)
+		for _, m := range t.Matches {
+			add(m.HomeTeam)
+			add(m.VisitingTeam)
+			add(m.Outcome.WinningTeam)
+		}
+	}
+
+	slog.Info("resolving team organizations", "team_count", len(pending))
+	progress.start(PhaseOpponents, len(pending))
+	var wg sync.WaitGroup
+	for _, t := range pending {
+		wg.Add(1)
+		go func(t *usta.Team) {
+			defer wg.Done()
+			defer progress.teamDone()
+			if err := n.provider.LoadTeamOrganization(ctx, t); err != nil {
+				usta.ReportFailure(ctx, usta.LoadFailure{What: "organization", TeamID: t.ID, Err: err})
+			}
+		}(t)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+const testLeagueYAML = `
+organizations:
+  - id: 1
+    name: RIVERSIDE TENNIS CLUB
+  - id: 2
+    name: HILLTOP RACQUET CLUB
+    address: 1 Hilltop Rd, San Jose, CA
+teams:
+  - id: 10
+    organization_id: 1
+    name: 2026 Adult 18 & Over Womens 3.5
+    code: RIVERSIDE 18AW3.5
+  - id: 20
+    organization_id: 2
+    name: 2026 Adult 18 & Over Womens 3.5
+    code: HILLTOP 18AW3.5
+matches:
+  - number: 1
+    home_team_id: 20
+    visiting_team_id: 10
+    date: "2026-04-22 18:00"
+`
+
+func TestGenerateResolvesSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+	p, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	n, err := NewNewsletter([]int{1}, nil)
+	require.NoError(t, err)
+	n.SetProvider(p)
+
+	snap := n.Snapshot()
+	require.Equal(t, []int{1}, snap.OrganizationIDs())
+	require.Nil(t, snap.Organization())
+
+	require.NoError(t, n.Generate(context.Background()))
+	snap = n.Snapshot()
+	require.Equal(t, "RIVERSIDE TENNIS CLUB", snap.Organization().Name)
+	require.Empty(t, snap.Failures())
+
+	matches := snap.Organization().Teams[0].Matches
+	require.Len(t, matches, 1)
+	home := matches[0].HomeTeam.Organization
+	require.NotNil(t, home)
+	require.Equal(t, "1 Hilltop Rd, San Jose, CA", home.Address)
+}
+
+func TestNewNewsletterNeedsAnOrganization(t *testing.T) {
+	_, err := NewNewsletter(nil, nil)
+	require.Error(t, err)
+}
+
+func TestGenerateReportsProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+	p, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	n, err := NewNewsletter([]int{1, 2}, nil)
+	require.NoError(t, err)
+	n.SetProvider(p)
+
+	var reports []Progress
+	n.SetProgress(func(p Progress) { reports = append(reports, p) })
+	require.NoError(t, n.Generate(context.Background()))
+
+	var phases []Phase
+	for _, r := range reports {
+		if len(phases) == 0 || phases[len(phases)-1] != r.Phase {
+			phases = append(phases, r.Phase)
+		}
+	}
+	require.Equal(t, []Phase{PhaseOrganizations, PhaseMatches, PhaseOpponents, PhaseDone}, phases)
+
+	for _, r := range reports {
+		if r.Phase == PhaseMatches && r.TeamsLoaded == r.TeamsTotal {
+			require.Equal(t, 2, r.TeamsTotal)
+			return
+		}
+	}
+	t.Fatal("no report of all teams' matches loaded")
+}
+
+// rosterFailingProvider is a league file whose rosters and flights fail to
+// load.
+type rosterFailingProvider struct {
+	*usta.FileProvider
+}
+
+func (p rosterFailingProvider) LoadRoster(ctx context.Context, t *usta.Team) error {
+	return errors.New("roster page unavailable")
+}
+
+func (p rosterFailingProvider) LoadFlight(ctx context.Context, t *usta.Team) error {
+	return errors.New("flight page unavailable")
+}
+
+func TestGenerateLogsRosterAndFlightFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+	p, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	n, err := NewNewsletter([]int{1}, nil)
+	require.NoError(t, err)
+	n.SetProvider(rosterFailingProvider{p})
+	require.NoError(t, n.Generate(context.Background()))
+
+	require.Len(t, n.Snapshot().Failures(), 2)
+	require.Contains(t, logs.String(), "what=roster")
+	require.Contains(t, logs.String(), `what="flight standings"`)
+}
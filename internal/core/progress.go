@@ -0,0 +1,87 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// Phase is a stage of Generate.
+type Phase string
+
+const (
+	// PhaseOrganizations loads the organizations and their teams.
+	PhaseOrganizations Phase = "organizations"
+	// PhaseExtraTeams loads the teams given with -teams.
+	PhaseExtraTeams Phase = "extra-teams"
+	// PhaseMatches loads each team's matches, roster and flight.
+	PhaseMatches Phase = "matches"
+	// PhaseOpponents loads the organization of every team played.
+	PhaseOpponents Phase = "opponents"
+	// PhaseDone is reported once Generate has finished.
+	PhaseDone Phase = "done"
+)
+
+// Progress reports how far Generate has got. Teams count the teams handled
+// in the current phase, or the organizations while loading those; pages and
+// errors count the whole run so far.
+type Progress struct {
+	Phase        Phase `json:"phase"`
+	TeamsLoaded  int   `json:"teams_loaded"`
+	TeamsTotal   int   `json:"teams_total"`
+	PagesFetched int   `json:"pages_fetched"`
+	CacheHits    int   `json:"cache_hits"`
+	Errors       int   `json:"errors"`
+}
+
+// ProgressFunc receives progress reports from Generate. Calls are never
+// concurrent, but come from Generate's goroutines, so it should return
+// quickly.
+type ProgressFunc func(Progress)
+
+// progressTracker gathers the progress of one call to Generate and reports
+// it to a ProgressFunc.
+type progressTracker struct {
+	mu     sync.Mutex
+	fn     ProgressFunc
+	p      Progress
+	stats  *usta.FetchStats
+	report *usta.LoadReport
+}
+
+func newProgressTracker(fn ProgressFunc, report *usta.LoadReport) *progressTracker {
+	t := &progressTracker{fn: fn, report: report}
+	t.stats = usta.NewFetchStats(t.emit)
+	return t
+}
+
+// start begins phase, with total teams to handle.
+func (t *progressTracker) start(phase Phase, total int) {
+	t.mu.Lock()
+	t.p.Phase = phase
+	t.p.TeamsLoaded = 0
+	t.p.TeamsTotal = total
+	t.mu.Unlock()
+	t.emit()
+}
+
+// teamDone counts a team handled in the current phase.
+func (t *progressTracker) teamDone() {
+	t.mu.Lock()
+	t.p.TeamsLoaded++
+	t.mu.Unlock()
+	t.emit()
+}
+
+// emit reports the current progress.
+func (t *progressTracker) emit() {
+	if t.fn == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.p.PagesFetched = t.stats.Fetched()
+	t.p.CacheHits = t.stats.CacheHits()
+	t.p.Errors = t.report.Count()
+	t.fn(t.p)
+}
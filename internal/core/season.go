@@ -0,0 +1,102 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// TeamSeason is one team's record over every match it has played this
+// season, not just those in the newsletter's past window. Rained out,
+// postponed and undecided matches don't count.
+type TeamSeason struct {
+	Team *usta.Team
+
+	Wins   int
+	Losses int
+	// PointsWon and PointsLost count the individual lines won and lost.
+	PointsWon  int
+	PointsLost int
+
+	HomeWins   int
+	HomeLosses int
+	AwayWins   int
+	AwayLosses int
+
+	// Streak is the number of matches won in a row up to the latest one, or
+	// lost in a row if negative.
+	Streak int
+}
+
+// Played returns the number of matches counted.
+func (s TeamSeason) Played() int {
+	return s.Wins + s.Losses
+}
+
+// SeasonSummary returns the season so far of each of org's teams, in order,
+// counting the matches dated before boundary, or every decided match if
+// boundary is zero. Teams' matches must be loaded, as they are in a snapshot
+// made by Newsletter.Generate.
+func SeasonSummary(org *usta.Organization, boundary time.Time) []TeamSeason {
+	seasons := make([]TeamSeason, len(org.Teams))
+	for i, t := range org.Teams {
+		seasons[i] = teamSeason(t, boundary)
+	}
+	return seasons
+}
+
+// decidedMatches returns t's matches with a winner dated before boundary,
+// or all of them if boundary is zero, in date order.
+func decidedMatches(t *usta.Team, boundary time.Time) []usta.Match {
+	var decided []usta.Match
+	for _, m := range t.Matches {
+		if m.Outcome.WinningTeam == nil || !boundary.IsZero() && !m.Date.Before(boundary) {
+			continue
+		}
+		decided = append(decided, m)
+	}
+	sort.SliceStable(decided, func(i, j int) bool {
+		return decided[i].Date.Before(decided[j].Date)
+	})
+	return decided
+}
+
+func teamSeason(t *usta.Team, boundary time.Time) TeamSeason {
+	s := TeamSeason{Team: t}
+	for _, m := range decidedMatches(t, boundary) {
+		won := m.Outcome.WinningTeam.ID == t.ID
+		home := m.HomeTeam.ID == t.ID
+		switch {
+		case won:
+			s.Wins++
+			s.PointsWon += m.Outcome.WinnerPoints
+			s.PointsLost += m.Outcome.LoserPoints
+		default:
+			s.Losses++
+			s.PointsWon += m.Outcome.LoserPoints
+			s.PointsLost += m.Outcome.WinnerPoints
+		}
+		switch {
+		case home && won:
+			s.HomeWins++
+		case home:
+			s.HomeLosses++
+		case won:
+			s.AwayWins++
+		default:
+			s.AwayLosses++
+		}
+		switch {
+		case won && s.Streak > 0:
+			s.Streak++
+		case won:
+			s.Streak = 1
+		case s.Streak < 0:
+			s.Streak--
+		default:
+			s.Streak = -1
+		}
+	}
+	return s
+}
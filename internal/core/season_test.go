@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestSeasonSummary(t *testing.T) {
+	ours := &usta.Team{ID: 1}
+	other := &usta.Team{ID: 2}
+	// Out of date order, to check the streak is counted from the latest.
+	ours.Matches = []usta.Match{
+		playedMatch(20, other, ours, ours, 3, 2),
+		playedMatch(1, ours, other, ours, 5, 0),
+		playedMatch(8, other, ours, other, 4, 1),
+		playedMatch(15, ours, other, ours, 3, 2),
+		{Date: day(22), HomeTeam: ours, VisitingTeam: other, Outcome: usta.Outcome{Kind: usta.OutcomeRainout}},
+		playedMatch(29, ours, other, other, 3, 2),
+	}
+	org := &usta.Organization{Teams: []*usta.Team{ours, {ID: 3}}}
+
+	seasons := SeasonSummary(org, day(25))
+	require.Len(t, seasons, 2)
+	require.Equal(t, TeamSeason{
+		Team:       ours,
+		Wins:       3,
+		Losses:     1,
+		PointsWon:  12,
+		PointsLost: 8,
+		HomeWins:   2,
+		AwayWins:   1,
+		AwayLosses: 1,
+		Streak:     2,
+	}, seasons[0])
+	require.Zero(t, seasons[1].Played())
+
+	s := SeasonSummary(org, time.Time{})[0]
+	require.Equal(t, 5, s.Played())
+	require.Equal(t, 1, s.HomeLosses)
+	require.Equal(t, -1, s.Streak)
+}
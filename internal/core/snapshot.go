@@ -0,0 +1,41 @@
+package core
+
+import (
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// Snapshot is the league data a newsletter is made from, as loaded by
+// Generate. Every team in every match has its organization, with its
+// address, so nothing made from a snapshot needs the network, except teams
+// whose organization failed to load, which have none and are listed in
+// Failures. A snapshot must not be modified once made.
+type Snapshot struct {
+	orgIDs   []int
+	orgs     []*usta.Organization
+	failures []usta.LoadFailure
+}
+
+// OrganizationIDs returns the IDs of the snapshot's organizations.
+func (s *Snapshot) OrganizationIDs() []int {
+	return s.orgIDs
+}
+
+// Organizations returns the snapshot's organizations, in the order their
+// IDs were given, or nil if their data wasn't loaded.
+func (s *Snapshot) Organizations() []*usta.Organization {
+	return s.orgs
+}
+
+// Organization returns the snapshot's first organization, or nil if its
+// data wasn't loaded.
+func (s *Snapshot) Organization() *usta.Organization {
+	if len(s.orgs) == 0 {
+		return nil
+	}
+	return s.orgs[0]
+}
+
+// Failures returns what failed to load while making the snapshot.
+func (s *Snapshot) Failures() []usta.LoadFailure {
+	return s.failures
+}
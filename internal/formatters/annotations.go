@@ -1,6 +1,10 @@
 package formatters
 
-import "github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+import (
+	"fmt"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
 
 type MatchType int
 
@@ -11,13 +15,54 @@ const (
 )
 
 type MatchAnnotation struct {
-	RainedOut  bool
-	Score      string
-	Footnote   string
-	MatchType  MatchType
+	RainedOut bool
+	Score     string
+	Footnote  string
+	MatchType MatchType
 }
 
 type AnnotatedMatch struct {
 	Match      usta.Match
 	Annotation MatchAnnotation
 }
+
+// matchAnnotation returns the annotation implied by what USTA already says
+// about a match: its type, and outcomes that weren't decided on court. Only
+// matches these can't account for need to be asked about.
+func matchAnnotation(m usta.Match) MatchAnnotation {
+	var a MatchAnnotation
+
+	switch m.Outcome.Kind {
+	case usta.OutcomeRainout:
+		a.RainedOut = true
+	case usta.OutcomePostponed:
+		a.Footnote = "postponed"
+	}
+
+	switch m.Type {
+	case usta.MatchTypePlayoff:
+		a.MatchType = Playoff
+	case usta.MatchTypeSectionals:
+		a.MatchType = Sectionals
+	}
+
+	return a
+}
+
+// outcomeText describes a decided match's outcome from our side, with sep
+// between the scores, e.g. "won 3-2", "lost 2-3 (ret.)" or "won by forfeit".
+// A match defaulted or forfeited as a whole is shown without its score.
+func outcomeText(o usta.Outcome, won bool, sep string) string {
+	verb, ours, theirs := "lost", o.LoserPoints, o.WinnerPoints
+	if won {
+		verb, ours, theirs = "won", o.WinnerPoints, o.LoserPoints
+	}
+	switch o.Kind {
+	case usta.OutcomeDefault, usta.OutcomeForfeit:
+		return verb + " by " + o.Kind.String()
+	case usta.OutcomeRetired:
+		return fmt.Sprintf("%s %d%s%d (ret.)", verb, ours, sep, theirs)
+	default:
+		return fmt.Sprintf("%s %d%s%d", verb, ours, sep, theirs)
+	}
+}
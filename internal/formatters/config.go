@@ -16,6 +16,12 @@ type Config struct {
 	OutputDir    string
 	DataFilePath string // path to intermediate JSON data file; loaded if found, saved otherwise
 
+	ShowGateCodes bool // include facility gate codes in calendar events instead of redacting them
+
+	// DisplayLocation, if set, is the time zone match times are shown in;
+	// otherwise they're shown in the league's time zone.
+	DisplayLocation *time.Location
+
 	Reader io.Reader
 	Writer io.Writer
 }
@@ -1,9 +1,7 @@
 package formatters
 
 import (
-	"context"
 	"fmt"
-	"io"
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
@@ -22,30 +20,54 @@ func (c *ConsoleFormatter) FormatRecent(data *PreparedData, cfg Config) error {
 	}
 
 	var str strings.Builder
-	str.WriteString("Recent matches:\n")
-	table := tablewriter.NewWriter(&str)
+	writeConsoleWarnings(&str, data)
+	for _, s := range data.sections() {
+		if !s.hasPastMatches() {
+			continue
+		}
+		str.WriteString(sectionHeading(data, s, "Recent matches") + ":\n")
+		writeConsoleHighlights(&str, s)
+		writeConsoleRecent(&str, s, cfg)
+		str.WriteString("\n")
+	}
+	fmt.Fprint(cfg.Writer, str.String())
+	return nil
+}
+
+// writeConsoleHighlights writes the highlights for one club, if any.
+func writeConsoleHighlights(str *strings.Builder, s *PreparedData) {
+	captions := s.highlightCaptions()
+	if len(captions) == 0 {
+		return
+	}
+	str.WriteString("Highlights:\n")
+	for _, c := range captions {
+		str.WriteString("  * " + c + "\n")
+	}
+}
+
+// writeConsoleRecent writes the recent matches table for one club.
+func writeConsoleRecent(str *strings.Builder, s *PreparedData, cfg Config) {
+	table := tablewriter.NewWriter(str)
 	table.SetAutoWrapText(false)
 
-	if data.DataFile != nil {
-		for _, rec := range data.DataFile.PastMatches {
+	if s.DataFile != nil {
+		for _, rec := range s.DataFile.PastMatches {
 			table.Append([]string{
 				dataFileDateDisplay(rec.Date),
-				data.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript,
+				s.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript,
 				consoleOutcome(rec),
 				consoleLocOpponent(rec.IsHome, rec.Opponent),
 			})
 		}
 	} else {
-		for _, am := range data.PastMatches {
-			date, first, outcome, locOpponent := formatAnnotatedMatch(am, data.Org, data.OrgNames, cfg.Reader, cfg.Writer)
+		for _, am := range s.PastMatches {
+			date, first, outcome, locOpponent := formatAnnotatedMatch(am, s.Org, s.OrgNames)
 			table.Append([]string{date, first, outcome, locOpponent})
 		}
 	}
 
 	table.Render()
-	str.WriteString("\n")
-	fmt.Fprint(cfg.Writer, str.String())
-	return nil
 }
 
 func (c *ConsoleFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
@@ -54,43 +76,157 @@ func (c *ConsoleFormatter) FormatUpcoming(data *PreparedData, cfg Config) error
 	}
 
 	var str strings.Builder
-	str.WriteString("Upcoming matches:\n")
-	table := tablewriter.NewWriter(&str)
+	writeConsoleWarnings(&str, data)
+	for _, s := range data.sections() {
+		if !s.hasUpcomingMatches() {
+			continue
+		}
+		str.WriteString(sectionHeading(data, s, "Upcoming matches") + ":\n")
+		writeConsoleUpcoming(&str, s, cfg)
+		str.WriteString("\n")
+	}
+	fmt.Fprint(cfg.Writer, str.String())
+	return nil
+}
+
+// writeConsoleUpcoming writes the upcoming matches table for one club.
+func writeConsoleUpcoming(str *strings.Builder, s *PreparedData, cfg Config) {
+	table := tablewriter.NewWriter(str)
 	table.SetAutoWrapText(false)
 
-	if data.DataFile != nil {
-		for _, rec := range data.DataFile.FutureMatches {
+	if s.DataFile != nil {
+		for _, rec := range s.DataFile.FutureMatches {
 			date := dataFileDateDisplay(rec.Date)
 			if rec.Time != "" {
-				date += " " + dataFileMatchTime(rec.Time)
+				date += " " + rec.displayTime()
 			}
 			locOpponent := consoleLocOpponent(rec.IsHome, rec.Opponent)
 			if rec.LocationNote != "" {
 				locOpponent += fmt.Sprintf(" (at %s)", rec.LocationNote)
 			}
+			if rec.Rescheduled != "" {
+				locOpponent += fmt.Sprintf(" (%s)", rec.Rescheduled)
+			}
 			table.Append([]string{
 				date,
-				data.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript,
+				s.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript,
 				locOpponent,
 			})
 		}
 	} else {
-		for i, m := range data.FutureMatches {
-			_, first, _, locOpponent := formatFutureMatch(m, data.Org, data.OrgNames, cfg.Reader, cfg.Writer)
-			if loc, ok := data.LocationOverrides[i]; ok {
+		for i, m := range s.FutureMatches {
+			_, first, _, locOpponent := formatFutureMatch(m, s.Org, s.OrgNames)
+			if loc, ok := s.LocationOverrides[i]; ok {
 				locOpponent += fmt.Sprintf(" (at %s)", loc)
 			}
+			if note := rescheduledNote(m); note != "" {
+				locOpponent += fmt.Sprintf(" (%s)", note)
+			}
 			date := m.Date.Format("Mon, Jan 02 03:04 PM")
 			table.Append([]string{date, first, locOpponent})
 		}
 	}
 
 	table.Render()
-	str.WriteString("\n")
+}
+
+func (c *ConsoleFormatter) FormatStandings(data *PreparedData, cfg Config) error {
+	if !data.hasStandings() {
+		return nil
+	}
+
+	var str strings.Builder
+	writeConsoleWarnings(&str, data)
+	for _, s := range data.sections() {
+		if !s.hasStandings() {
+			continue
+		}
+		str.WriteString(sectionHeading(data, s, "Standings") + ":\n")
+		writeConsoleStandings(&str, s, cfg)
+		str.WriteString("\n")
+	}
+	fmt.Fprint(cfg.Writer, str.String())
+	return nil
+}
+
+// writeConsoleStandings writes the standings tables for one club.
+func writeConsoleStandings(str *strings.Builder, s *PreparedData, cfg Config) {
+	for _, f := range s.standings() {
+		fmt.Fprintf(str, "%s (%s)\n", f.Teams, f.Flight)
+		table := tablewriter.NewWriter(str)
+		table.SetAutoWrapText(false)
+		table.SetAutoFormatHeaders(false)
+		table.SetHeader([]string{"#", "Team", "W-L", "Ind. %", "Left"})
+		for _, r := range f.Rows {
+			team := r.Team
+			if r.IsOurs {
+				team = "» " + team
+			}
+			table.Append([]string{
+				fmt.Sprint(r.Position),
+				team,
+				fmt.Sprintf("%d-%d", r.Wins, r.Losses),
+				fmt.Sprintf("%.0f%%", r.IndividualWinPct),
+				fmt.Sprint(r.Remaining),
+			})
+		}
+		table.Render()
+	}
+}
+
+func (c *ConsoleFormatter) FormatSeason(data *PreparedData, cfg Config) error {
+	if !data.hasSeason() {
+		return nil
+	}
+
+	var str strings.Builder
+	writeConsoleWarnings(&str, data)
+	for _, s := range data.sections() {
+		if !s.hasSeason() {
+			continue
+		}
+		str.WriteString(sectionHeading(data, s, "Season so far") + ":\n")
+		writeConsoleSeason(&str, s)
+		str.WriteString("\n")
+	}
 	fmt.Fprint(cfg.Writer, str.String())
 	return nil
 }
 
+// writeConsoleSeason writes the season so far table for one club.
+func writeConsoleSeason(str *strings.Builder, s *PreparedData) {
+	table := tablewriter.NewWriter(str)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{"Team", "W-L", "Points", "Home", "Away", "Streak"})
+	for _, r := range s.season() {
+		table.Append([]string{
+			r.Team,
+			fmt.Sprintf("%d-%d", r.Wins, r.Losses),
+			fmt.Sprintf("%d-%d", r.PointsWon, r.PointsLost),
+			fmt.Sprintf("%d-%d", r.HomeWins, r.HomeLosses),
+			fmt.Sprintf("%d-%d", r.AwayWins, r.AwayLosses),
+			r.StreakText(),
+		})
+	}
+	table.Render()
+}
+
+// writeConsoleWarnings writes a banner listing data that failed to load,
+// unless an earlier section of the run already has.
+func writeConsoleWarnings(str *strings.Builder, data *PreparedData) {
+	warnings := data.warnings()
+	if len(warnings) == 0 || data.consoleWarned {
+		return
+	}
+	data.consoleWarned = true
+	str.WriteString("WARNING: some USTA data failed to load; this newsletter may be incomplete.\n")
+	for _, w := range warnings {
+		str.WriteString("  - " + w + "\n")
+	}
+	str.WriteString("\n")
+}
+
 func consoleOutcome(rec PastMatchRecord) string {
 	var outcome string
 	if rec.IsRainedOut {
@@ -118,14 +254,31 @@ func consoleLocOpponent(isHome bool, opponent string) string {
 	return "@ " + opponent
 }
 
-func formatAnnotatedMatch(am AnnotatedMatch, org *usta.Organization, names *OrgNames, reader io.Reader, writer io.Writer) (date, first, outcome, locOpponent string) {
+// consoleOpponentName returns the opponent's name for console output. In a
+// club derby it's our other team, e.g. "ASRC 3.5B".
+func consoleOpponentName(m usta.Match, org *usta.Organization, opponent *usta.Team, names *OrgNames) string {
+	if m.Derby {
+		return org.ShortName() + " " + opponent.ShortName()
+	}
+	return opponentDisplayName(names, opponent)
+}
+
+// consoleTeamName returns how to show one of our teams, e.g. "ASRC 3.5A", or
+// just the team's short name if its club couldn't be loaded.
+func consoleTeamName(t *usta.Team) string {
+	if t.Organization == nil {
+		return t.ShortName()
+	}
+	return t.Organization.ShortName() + " " + t.ShortName()
+}
+
+func formatAnnotatedMatch(am AnnotatedMatch, org *usta.Organization, names *OrgNames) (date, first, outcome, locOpponent string) {
 	m := am.Match
 	ourTeam, opponent, isHome := resolveTeams(m, org)
-	opponent.LoadOrganization(context.Background())
 
 	date = m.Date.Format("Mon, Jan 02")
-	first = ourTeam.Organization.ShortName() + " " + ourTeam.ShortName()
-	opName := opponentDisplayName(names, reader, writer, opponent.Organization)
+	first = consoleTeamName(ourTeam)
+	opName := consoleOpponentName(m, org, opponent, names)
 
 	locator := "vs."
 	if !isHome {
@@ -140,12 +293,7 @@ func formatAnnotatedMatch(am AnnotatedMatch, org *usta.Organization, names *OrgN
 	} else if am.Annotation.Footnote != "" {
 		outcome = "*"
 	} else if m.Outcome.WinningTeam != nil {
-		m.Outcome.WinningTeam.LoadOrganization(context.Background())
-		if m.Outcome.WinningTeam.Organization.Equals(ourTeam.Organization) || m.Outcome.WinningTeam == ourTeam {
-			outcome = fmt.Sprintf("won %d - %d", m.Outcome.WinnerPoints, m.Outcome.LoserPoints)
-		} else {
-			outcome = fmt.Sprintf("lost %d - %d", m.Outcome.LoserPoints, m.Outcome.WinnerPoints)
-		}
+		outcome = outcomeText(m.Outcome, ourTeamWon(m, ourTeam), " - ")
 	}
 
 	if am.Annotation.MatchType == Playoff {
@@ -157,13 +305,12 @@ func formatAnnotatedMatch(am AnnotatedMatch, org *usta.Organization, names *OrgN
 	return
 }
 
-func formatFutureMatch(m usta.Match, org *usta.Organization, names *OrgNames, reader io.Reader, writer io.Writer) (date, first, outcome, locOpponent string) {
+func formatFutureMatch(m usta.Match, org *usta.Organization, names *OrgNames) (date, first, outcome, locOpponent string) {
 	ourTeam, opponent, isHome := resolveTeams(m, org)
-	opponent.LoadOrganization(context.Background())
 
 	date = m.Date.Format("Mon, Jan 02 03:04 PM")
-	first = ourTeam.Organization.ShortName() + " " + ourTeam.ShortName()
-	opName := opponentDisplayName(names, reader, writer, opponent.Organization)
+	first = consoleTeamName(ourTeam)
+	opName := consoleOpponentName(m, org, opponent, names)
 
 	locator := "vs."
 	if !isHome {
@@ -0,0 +1,112 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/core"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+const testConsoleLeagueYAML = `
+organizations:
+  - id: 1
+    name: RIVERSIDE TENNIS CLUB
+  - id: 2
+    name: HILLTOP RACQUET CLUB
+teams:
+  - id: 10
+    organization_id: 1
+    name: 2026 Adult 18 & Over Womens 3.5
+    code: RIVERSIDE 18AW3.5
+  - id: 20
+    organization_id: 2
+    name: 2026 Adult 18 & Over Mens 4.0
+    code: HILLTOP 18AM4.0
+matches:
+  - number: 1
+    home_team_id: 20
+    visiting_team_id: 10
+    date: "2026-04-22 18:00"
+`
+
+// orgFailingProvider is a league file whose organization page for one team
+// fails to load.
+type orgFailingProvider struct {
+	*usta.FileProvider
+	teamID int
+}
+
+func (p orgFailingProvider) LoadTeamOrganization(ctx context.Context, t *usta.Team) error {
+	if t.ID != p.teamID {
+		return p.FileProvider.LoadTeamOrganization(ctx, t)
+	}
+	t.Organization = nil
+	return errors.New("organization page unavailable")
+}
+
+func TestConsoleFormatsExtraTeamWithoutOrganization(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testConsoleLeagueYAML), 0644))
+	fp, err := usta.NewFileProvider(path)
+	require.NoError(t, err)
+
+	n, err := core.NewNewsletter([]int{1}, []int{20})
+	require.NoError(t, err)
+	n.SetProvider(orgFailingProvider{FileProvider: fp, teamID: 20})
+	require.NoError(t, n.Generate(context.Background()))
+
+	snap := n.Snapshot()
+	require.Len(t, snap.Failures(), 1)
+	org := snap.Organization()
+
+	_, future := org.Matches(0, 7*24*time.Hour, time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC))
+	require.Len(t, future, 1)
+	require.Nil(t, future[0].HomeTeam.Organization)
+
+	// Our extra team, whose club failed to load, is shown by its own name.
+	_, first, _, _ := formatFutureMatch(future[0], org, makeTestOrgNames())
+	require.Equal(t, future[0].HomeTeam.ShortName(), first)
+
+	past, _ := org.Matches(7*24*time.Hour, 0, time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC))
+	require.Len(t, past, 1)
+	_, first, _, _ = formatAnnotatedMatch(AnnotatedMatch{Match: past[0]}, org, makeTestOrgNames())
+	require.Equal(t, past[0].HomeTeam.ShortName(), first)
+}
+
+func TestConsoleShowsWarningsOnce(t *testing.T) {
+	data := &PreparedData{DataFile: &DataFile{
+		OrgShortName: "RTC",
+		PastMatches: []PastMatchRecord{
+			{Date: "2026-04-14", GenderEmoji: "👭", Level: "3.5", IsHome: true, Opponent: "Hilltop", IsWin: true, OutcomeText: "won 3-2"},
+		},
+		FutureMatches: []FutureMatchRecord{
+			{Date: "2026-04-22", GenderEmoji: "👭", Level: "3.5", Opponent: "Hilltop"},
+		},
+		Standings: []FlightStandingsRecord{
+			{Flight: "3.5 Flight 1", Teams: "👭3.5", Rows: []StandingRecord{{Position: 1, Team: "RTC", IsOurs: true, Wins: 1}}},
+		},
+		Season:   []SeasonRecord{{Team: "👭3.5", Wins: 1, PointsWon: 3, PointsLost: 2, Streak: 1}},
+		Warnings: []string{"organization 2: organization page unavailable"},
+	}}
+
+	// A run formats each section in turn, with its own formatters.
+	var buf bytes.Buffer
+	cfg := Config{Writer: &buf}
+	require.NoError(t, NewConsoleFormatter().FormatRecent(data, cfg))
+	require.NoError(t, NewConsoleFormatter().FormatStandings(data, cfg))
+	require.NoError(t, NewConsoleFormatter().FormatSeason(data, cfg))
+	require.NoError(t, NewConsoleFormatter().FormatUpcoming(data, cfg))
+
+	out := buf.String()
+	require.Equal(t, 1, strings.Count(out, "WARNING:"))
+	require.Equal(t, 1, strings.Count(out, "organization page unavailable"))
+	require.True(t, strings.HasPrefix(out, "WARNING:"), out)
+}
@@ -1,10 +1,8 @@
 package formatters
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -16,31 +14,38 @@ import (
 // DataFile is the intermediate JSON data saved alongside output files.
 // Edit any "date" field to correct a wrong date, then re-run to regenerate the report.
 type DataFile struct {
-	OrgShortName  string              `json:"org_short_name"`
-	PastMatches   []PastMatchRecord   `json:"past_matches"`
-	FutureMatches []FutureMatchRecord `json:"future_matches"`
+	OrgShortName  string                  `json:"org_short_name"`
+	PastMatches   []PastMatchRecord       `json:"past_matches"`
+	FutureMatches []FutureMatchRecord     `json:"future_matches"`
+	Standings     []FlightStandingsRecord `json:"standings,omitempty"`
+	Season        []SeasonRecord          `json:"season,omitempty"`
+	Highlights    []HighlightRecord       `json:"highlights,omitempty"`
+	Warnings      []string                `json:"warnings,omitempty"` // data that failed to load from USTA
 }
 
 // PastMatchRecord is a human-editable record for a single past match.
 type PastMatchRecord struct {
-	Date         string `json:"date"`                    // YYYY-MM-DD; change to correct wrong dates
+	Date         string `json:"date"` // YYYY-MM-DD; change to correct wrong dates
 	GenderEmoji  string `json:"gender_emoji"`
 	Level        string `json:"level"`
-	Superscript  string `json:"superscript,omitempty"`   // team suffix: "A", "B", etc.
+	Superscript  string `json:"superscript,omitempty"` // team suffix: "A", "B", etc.
 	IsHome       bool   `json:"is_home"`
 	Opponent     string `json:"opponent"`
 	IsWin        bool   `json:"is_win,omitempty"`
 	IsRainedOut  bool   `json:"is_rained_out,omitempty"`
 	IsIncomplete bool   `json:"is_incomplete,omitempty"`
-	OutcomeText  string `json:"outcome_text,omitempty"`  // "won 2-1" or partial score
+	OutcomeText  string `json:"outcome_text,omitempty"` // "won 2-1" or partial score
 	Footnote     string `json:"footnote,omitempty"`
 	MatchType    string `json:"match_type,omitempty"` // "regular", "playoff", "sectionals"
+	Derby        bool   `json:"derby,omitempty"`      // both teams are ours
 }
 
 // FutureMatchRecord is a human-editable record for a single upcoming match.
 type FutureMatchRecord struct {
-	Date         string `json:"date"`                    // YYYY-MM-DD; change to correct wrong dates
-	Time         string `json:"time,omitempty"`          // HH:MM in 24-hour format
+	Date         string `json:"date"`                  // YYYY-MM-DD; change to correct wrong dates
+	Time         string `json:"time,omitempty"`        // HH:MM in 24-hour format
+	StartTimes   string `json:"start_times,omitempty"` // staggered start times, e.g. "6pm (L1-2), 7:30pm (L3)"
+	Courts       string `json:"courts,omitempty"`      // e.g. "Cts 7, 8, 9"
 	GenderEmoji  string `json:"gender_emoji"`
 	Level        string `json:"level"`
 	Superscript  string `json:"superscript,omitempty"`
@@ -48,37 +53,43 @@ type FutureMatchRecord struct {
 	Opponent     string `json:"opponent"`
 	LocationNote string `json:"location_note,omitempty"` // alternate location for away extra-team matches
 	MatchType    string `json:"match_type,omitempty"`    // "regular", "playoff", "sectionals"
+	Derby        bool   `json:"derby,omitempty"`         // both teams are ours
+	Rescheduled  string `json:"rescheduled,omitempty"`   // e.g. "rescheduled from Tue 4/14"
 }
 
 // NewDataFile builds a DataFile from a PreparedData populated via live USTA data.
-func NewDataFile(data *PreparedData, reader io.Reader, writer io.Writer) *DataFile {
+func NewDataFile(data *PreparedData) *DataFile {
 	df := &DataFile{
 		OrgShortName: data.Org.ShortName(),
+		Standings:    data.Standings,
+		Season:       data.Season,
+		Highlights:   data.Highlights,
+		Warnings:     data.Warnings,
 	}
 	for _, am := range data.PastMatches {
-		df.PastMatches = append(df.PastMatches, buildPastRecord(am, data.Org, data.OrgNames, reader, writer))
+		df.PastMatches = append(df.PastMatches, buildPastRecord(am, data.Org, data.OrgNames))
 	}
 	for i, m := range data.FutureMatches {
 		loc := data.LocationOverrides[i]
-		df.FutureMatches = append(df.FutureMatches, buildFutureRecord(m, data.Org, data.OrgNames, loc, reader, writer))
+		df.FutureMatches = append(df.FutureMatches, buildFutureRecord(m, data.Org, data.OrgNames, loc))
 	}
 	return df
 }
 
-func buildPastRecord(am AnnotatedMatch, org *usta.Organization, names *OrgNames, reader io.Reader, writer io.Writer) PastMatchRecord {
+func buildPastRecord(am AnnotatedMatch, org *usta.Organization, names *OrgNames) PastMatchRecord {
 	m := am.Match
 	ourTeam, opponent, isHome := resolveTeams(m, org)
 	d := ourTeam.Display()
-	opponent.LoadOrganization(context.Background())
 
 	rec := PastMatchRecord{
 		Date:        m.Date.Format("2006-01-02"),
 		GenderEmoji: d.GenderEmoji(),
-		Level:       d.Level,
+		Level:       d.Label(),
 		Superscript: suffixForTeam(org, ourTeam),
 		IsHome:      isHome,
-		Opponent:    opponentDisplayName(names, reader, writer, opponent.Organization),
+		Opponent:    matchOpponentName(m, org, opponent, names),
 		MatchType:   matchTypeToString(am.Annotation.MatchType),
+		Derby:       m.Derby,
 	}
 
 	if am.Annotation.RainedOut {
@@ -91,31 +102,29 @@ func buildPastRecord(am AnnotatedMatch, org *usta.Organization, names *OrgNames,
 		rec.IsIncomplete = true
 		rec.Footnote = am.Annotation.Footnote
 	} else if m.Outcome.WinningTeam != nil {
-		m.Outcome.WinningTeam.LoadOrganization(context.Background())
-		if m.Outcome.WinningTeam.Organization.Equals(ourTeam.Organization) || m.Outcome.WinningTeam == ourTeam {
-			rec.IsWin = true
-			rec.OutcomeText = fmt.Sprintf("won %d-%d", m.Outcome.WinnerPoints, m.Outcome.LoserPoints)
-		} else {
-			rec.OutcomeText = fmt.Sprintf("lost %d-%d", m.Outcome.LoserPoints, m.Outcome.WinnerPoints)
-		}
+		rec.IsWin = ourTeamWon(m, ourTeam)
+		rec.OutcomeText = outcomeText(m.Outcome, rec.IsWin, "-")
 	}
 
 	return rec
 }
 
-func buildFutureRecord(m usta.Match, org *usta.Organization, names *OrgNames, locationNote string, reader io.Reader, writer io.Writer) FutureMatchRecord {
+func buildFutureRecord(m usta.Match, org *usta.Organization, names *OrgNames, locationNote string) FutureMatchRecord {
 	ourTeam, opponent, isHome := resolveTeams(m, org)
 	d := ourTeam.Display()
-	opponent.LoadOrganization(context.Background())
 
 	rec := FutureMatchRecord{
 		Date:         m.Date.Format("2006-01-02"),
 		GenderEmoji:  d.GenderEmoji(),
-		Level:        d.Level,
+		Level:        d.Label(),
 		Superscript:  suffixForTeam(org, ourTeam),
 		IsHome:       isHome,
-		Opponent:     opponentDisplayName(names, reader, writer, opponent.Organization),
+		Opponent:     matchOpponentName(m, org, opponent, names),
 		LocationNote: locationNote,
+		StartTimes:   scheduleTimes(m.Schedule),
+		Courts:       scheduleCourts(m.Schedule),
+		Derby:        m.Derby,
+		Rescheduled:  rescheduledNote(m),
 	}
 	if m.HasTime {
 		rec.Time = m.Date.Format("15:04")
@@ -123,6 +132,15 @@ func buildFutureRecord(m usta.Match, org *usta.Organization, names *OrgNames, lo
 	return rec
 }
 
+// displayTime returns the record's start time for display, or its staggered
+// start times if its lines don't all start together.
+func (rec FutureMatchRecord) displayTime() string {
+	if rec.StartTimes != "" {
+		return rec.StartTimes
+	}
+	return dataFileMatchTime(rec.Time)
+}
+
 func matchTypeToString(mt MatchType) string {
 	switch mt {
 	case Playoff:
@@ -194,7 +212,7 @@ func (df *DataFile) ToRecentResultsData() RecentResultsData {
 			TeamSuperscript: teamSuperscript(rec.Superscript),
 			LocatorEmoji:    locationEmoji(rec.IsHome),
 			OpponentName:    rec.Opponent,
-			Tag:             matchTypeTag(matchTypeFromString(rec.MatchType)),
+			Tag:             matchTag(matchTypeFromString(rec.MatchType), rec.Derby),
 			IsWeekend:       isWeekend(t.Weekday()),
 			IsWin:           rec.IsWin,
 			IsRainedOut:     rec.IsRainedOut,
@@ -257,9 +275,9 @@ func (df *DataFile) ToUpcomingMatchesData() UpcomingMatchesData {
 	footnoteIndex := map[string]int{}
 
 	type timedMatch struct {
-		sortKey string
+		sortKey   string
 		isMorning bool
-		match   CalendarMatch
+		match     CalendarMatch
 	}
 	timedByDay := make([][]timedMatch, 7)
 
@@ -280,12 +298,14 @@ func (df *DataFile) ToUpcomingMatchesData() UpcomingMatchesData {
 
 		cm := CalendarMatch{
 			LocatorEmoji:    locationEmoji(rec.IsHome),
-			Time:            dataFileMatchTime(rec.Time),
+			Time:            rec.displayTime(),
+			Courts:          rec.Courts,
 			GenderEmoji:     rec.GenderEmoji,
 			Level:           rec.Level,
 			TeamSuperscript: teamSuperscript(rec.Superscript),
 			OpponentName:    rec.Opponent,
-			Tag:             matchTypeTag(matchTypeFromString(rec.MatchType)),
+			Tag:             matchTag(matchTypeFromString(rec.MatchType), rec.Derby),
+			Rescheduled:     rec.Rescheduled,
 		}
 
 		if rec.LocationNote != "" {
@@ -7,3 +7,11 @@ type RecentFormatter interface {
 type UpcomingFormatter interface {
 	FormatUpcoming(data *PreparedData, cfg Config) error
 }
+
+type StandingsFormatter interface {
+	FormatStandings(data *PreparedData, cfg Config) error
+}
+
+type SeasonFormatter interface {
+	FormatSeason(data *PreparedData, cfg Config) error
+}
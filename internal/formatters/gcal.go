@@ -19,9 +19,18 @@ type GCalFormatter struct {
 }
 
 func (g *GCalFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
-	if data.DataFile != nil {
-		slog.Warn("gcal formatter requires live USTA data; skipping because a data file was loaded")
-		fmt.Fprintln(cfg.Writer, "Note: Google Calendar update skipped — delete data.json and re-run to sync from live USTA data")
+	// Each club in a combined newsletter may have been loaded from a data
+	// file; only the live ones can be synced.
+	var live []*PreparedData
+	for _, s := range data.sections() {
+		if s.DataFile != nil {
+			slog.Warn("gcal formatter requires live USTA data; skipping because a data file was loaded", "org", s.orgShortName())
+			fmt.Fprintln(cfg.Writer, "Note: Google Calendar update skipped — delete data.json and re-run to sync from live USTA data")
+			continue
+		}
+		live = append(live, s)
+	}
+	if len(live) == 0 {
 		return nil
 	}
 
@@ -39,9 +48,11 @@ func (g *GCalFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 
 	slog.Info("using Google Calendar", "name", g.CalendarName, "id", calID)
 
-	for _, m := range data.FutureMatches {
-		if err := upsertEvent(ctx, svc, calID, m, data, cfg); err != nil {
-			slog.Error("failed to upsert calendar event", "match_number", m.Number, "error", err)
+	for _, s := range live {
+		for _, m := range s.FutureMatches {
+			if err := upsertEvent(ctx, svc, calID, m, s, cfg); err != nil {
+				slog.Error("failed to upsert calendar event", "match_number", m.Number, "error", err)
+			}
 		}
 	}
 
@@ -70,14 +81,12 @@ func eventID(teamID, matchNumber int) string {
 func upsertEvent(ctx context.Context, svc *calendar.Service, calID string, m usta.Match, data *PreparedData, cfg Config) error {
 	ourTeam, opponent, isHome := resolveTeams(m, data.Org)
 	d := ourTeam.Display()
-	opponent.LoadOrganization(ctx)
-
-	opponentName := data.OrgNames.Resolve(cfg.Reader, cfg.Writer, opponent.Organization.Name)
+	opponentName := matchOpponentName(m, data.Org, opponent, data.OrgNames)
 
 	title := fmt.Sprintf("%s %s%s%s%s %s %s",
 		locationEmoji(isHome),
 		d.GenderEmoji(),
-		d.Level,
+		d.Label(),
 		suffixForTeam(data.Org, ourTeam),
 		d.DaytimeEmoji(),
 		locatorWord(isHome),
@@ -85,10 +94,8 @@ func upsertEvent(ctx context.Context, svc *calendar.Service, calID string, m ust
 	)
 
 	var location string
-	m.HomeTeam.LoadOrganization(ctx)
-	m.HomeTeam.Organization.LoadAddress()
-	if m.HomeTeam.Organization.Address != "" {
-		location = m.HomeTeam.Organization.Address
+	if o := m.HomeTeam.Organization; o != nil {
+		location = o.Address
 	}
 
 	start := m.Date
@@ -98,15 +105,16 @@ func upsertEvent(ctx context.Context, svc *calendar.Service, calID string, m ust
 	end := start.Add(3 * time.Hour)
 
 	event := &calendar.Event{
-		Summary:  strings.TrimSpace(title),
-		Location: location,
+		Summary:     strings.TrimSpace(title),
+		Description: scheduleDescription(m, cfg.ShowGateCodes),
+		Location:    location,
 		Start: &calendar.EventDateTime{
 			DateTime: start.Format(time.RFC3339),
-			TimeZone: "America/Los_Angeles",
+			TimeZone: start.Location().String(),
 		},
 		End: &calendar.EventDateTime{
 			DateTime: end.Format(time.RFC3339),
-			TimeZone: "America/Los_Angeles",
+			TimeZone: end.Location().String(),
 		},
 	}
 
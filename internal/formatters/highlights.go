@@ -0,0 +1,88 @@
+package formatters
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/core"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// maxHighlights is the most highlights a newsletter shows.
+const maxHighlights = 5
+
+// HighlightRecord is one captioned highlight, as saved in the data file.
+type HighlightRecord struct {
+	Kind    string `json:"kind"`    // e.g. "sweep" or "win-streak"
+	Caption string `json:"caption"` // edit to reword; remove the record to drop it
+}
+
+// BuildHighlights returns captions for the most notable events for the
+// club's teams in the past duration before boundary.
+func BuildHighlights(org *usta.Organization, names *OrgNames, past time.Duration, boundary time.Time) []HighlightRecord {
+	var records []HighlightRecord
+	for _, h := range core.Highlights(org, past, boundary) {
+		if len(records) == maxHighlights {
+			break
+		}
+		records = append(records, HighlightRecord{
+			Kind:    string(h.Kind),
+			Caption: highlightCaption(h, org, names),
+		})
+	}
+	return records
+}
+
+// highlightCaption returns a one-line caption for h, e.g. "👭3.5 swept AVAC
+// 5-0".
+func highlightCaption(h core.Highlight, org *usta.Organization, names *OrgNames) string {
+	team := teamLabel(org, h.Team)
+	o := h.Match.Outcome
+	switch h.Kind {
+	case core.HighlightSectionals:
+		return team + " are going to Sectionals!"
+	case core.HighlightPlayoffs:
+		return team + " are in the playoffs!"
+	case core.HighlightClinched:
+		return team + " clinched first place in their flight"
+	case core.HighlightRevenge:
+		return fmt.Sprintf("%s avenged an early-season loss to %s, winning %d-%d", team, highlightOpponentName(h.Opponent, org, names), o.WinnerPoints, o.LoserPoints)
+	case core.HighlightSweep:
+		return fmt.Sprintf("%s swept %s %d-%d", team, highlightOpponentName(h.Opponent, org, names), o.WinnerPoints, o.LoserPoints)
+	case core.HighlightFirstWin:
+		return fmt.Sprintf("%s got their first win of the season, %d-%d over %s", team, o.WinnerPoints, o.LoserPoints, highlightOpponentName(h.Opponent, org, names))
+	case core.HighlightWinStreak:
+		return fmt.Sprintf("%s have won %d in a row", team, h.Count)
+	case core.HighlightLossStreak:
+		return fmt.Sprintf("%s are looking to bounce back after %d straight losses", team, h.Count)
+	default:
+		return team
+	}
+}
+
+// highlightOpponentName returns how to name an opponent in a caption: our
+// other team's label in a club derby, otherwise the opposing club's display
+// name.
+func highlightOpponentName(opponent *usta.Team, org *usta.Organization, names *OrgNames) string {
+	if org.HasTeam(opponent.ID) {
+		return org.ShortName() + " " + teamLabel(org, opponent)
+	}
+	return opponentDisplayName(names, opponent)
+}
+
+// highlights returns the highlights from either live data or the data file.
+func (d *PreparedData) highlights() []HighlightRecord {
+	if d.DataFile != nil {
+		return d.DataFile.Highlights
+	}
+	return d.Highlights
+}
+
+// highlightCaptions returns the captions of the highlights to show.
+func (d *PreparedData) highlightCaptions() []string {
+	var captions []string
+	for _, h := range d.highlights() {
+		captions = append(captions, h.Caption)
+	}
+	return captions
+}
@@ -0,0 +1,42 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestBuildHighlights(t *testing.T) {
+	org := makeTestOrg()
+	opponent := &usta.Team{ID: 2, Organization: &usta.Organization{ID: 300, Name: "Almaden Valley Athletic Club"}}
+	ours := &usta.Team{ID: 1, Name: "Adult 18+ Womens 3.5", Organization: org}
+	ours.Matches = []usta.Match{{
+		Date:         time.Date(2026, 4, 21, 18, 0, 0, 0, time.UTC),
+		HomeTeam:     ours,
+		VisitingTeam: opponent,
+		Outcome:      usta.Outcome{Kind: usta.OutcomePlayed, WinningTeam: ours, WinnerPoints: 5},
+	}}
+	org.Teams = []*usta.Team{ours}
+
+	records := BuildHighlights(org, makeTestOrgNames(), 7*24*time.Hour, time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, []HighlightRecord{
+		{Kind: "sweep", Caption: "👭3.5 swept AVAC 5-0"},
+		{Kind: "first-win", Caption: "👭3.5 got their first win of the season, 5-0 over AVAC"},
+	}, records)
+
+	data := &PreparedData{DataFile: &DataFile{
+		OrgShortName: "ASRC",
+		PastMatches:  []PastMatchRecord{{Date: "2026-04-21", GenderEmoji: "👭", Level: "3.5", IsWin: true, OutcomeText: "won 5-0", Opponent: "AVAC"}},
+		Highlights:   records,
+	}}
+	html, err := RenderRecentResultsHTML(data.buildRecentDisplay(Config{}))
+	require.NoError(t, err)
+	require.Contains(t, html, "<li>👭3.5 swept AVAC 5-0</li>")
+
+	output := &bytes.Buffer{}
+	require.NoError(t, NewConsoleFormatter().FormatRecent(data, Config{Writer: output}))
+	require.Contains(t, output.String(), "Highlights:\n  * 👭3.5 swept AVAC 5-0\n")
+}
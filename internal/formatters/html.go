@@ -16,10 +16,9 @@ func (f *HTMLFormatter) FormatRecent(data *PreparedData, cfg Config) error {
 		return nil
 	}
 
-	recent := data.buildRecentDisplay(cfg)
-	html, err := RenderRecentResultsHTML(recent)
+	html, err := recentPageHTML(data, cfg, true)
 	if err != nil {
-		return fmt.Errorf("rendering recent results HTML: %w", err)
+		return err
 	}
 	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "recent", "html"))
 	if err != nil {
@@ -38,10 +37,9 @@ func (f *HTMLFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 		return nil
 	}
 
-	upcoming := data.buildUpcomingDisplay(cfg)
-	html, err := RenderUpcomingMatchesHTML(upcoming)
+	html, err := upcomingPageHTML(data, cfg, true)
 	if err != nil {
-		return fmt.Errorf("rendering upcoming matches HTML: %w", err)
+		return err
 	}
 	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "upcoming", "html"))
 	if err != nil {
@@ -54,3 +52,132 @@ func (f *HTMLFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 
 	return nil
 }
+
+func (f *HTMLFormatter) FormatStandings(data *PreparedData, cfg Config) error {
+	if !data.hasStandings() {
+		return nil
+	}
+
+	html, err := standingsPageHTML(data, true)
+	if err != nil {
+		return err
+	}
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "standings", "html"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(html), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+
+	return nil
+}
+
+func (f *HTMLFormatter) FormatSeason(data *PreparedData, cfg Config) error {
+	if !data.hasSeason() {
+		return nil
+	}
+
+	html, err := seasonPageHTML(data, true)
+	if err != nil {
+		return err
+	}
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "season", "html"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(html), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+
+	return nil
+}
+
+// recentPageHTML renders the recent results page, with a section per club for a
+// combined newsletter. The load warnings head the page if withWarnings is set.
+func recentPageHTML(data *PreparedData, cfg Config, withWarnings bool) (string, error) {
+	var docs []string
+	for _, s := range data.sections() {
+		if !s.hasPastMatches() {
+			continue
+		}
+		recent := s.buildRecentDisplay(cfg)
+		if withWarnings && len(docs) == 0 {
+			recent.Warnings = data.warnings()
+		}
+		html, err := RenderRecentResultsHTML(recent)
+		if err != nil {
+			return "", fmt.Errorf("rendering recent results HTML: %w", err)
+		}
+		docs = append(docs, html)
+	}
+	return combineHTMLDocuments(docs), nil
+}
+
+// upcomingPageHTML renders the upcoming matches page, with a section per club
+// for a combined newsletter. The load warnings head the page if withWarnings
+// is set.
+func upcomingPageHTML(data *PreparedData, cfg Config, withWarnings bool) (string, error) {
+	var docs []string
+	for _, s := range data.sections() {
+		if !s.hasUpcomingMatches() {
+			continue
+		}
+		upcoming := s.buildUpcomingDisplay(cfg)
+		if withWarnings && len(docs) == 0 {
+			upcoming.Warnings = data.warnings()
+		}
+		html, err := RenderUpcomingMatchesHTML(upcoming)
+		if err != nil {
+			return "", fmt.Errorf("rendering upcoming matches HTML: %w", err)
+		}
+		docs = append(docs, html)
+	}
+	return combineHTMLDocuments(docs), nil
+}
+
+// standingsPageHTML renders the standings page, with a section per club for a
+// combined newsletter. The load warnings head the page if withWarnings is
+// set.
+func standingsPageHTML(data *PreparedData, withWarnings bool) (string, error) {
+	var docs []string
+	for _, s := range data.sections() {
+		if !s.hasStandings() {
+			continue
+		}
+		standings := s.buildStandingsDisplay()
+		if withWarnings && len(docs) == 0 {
+			standings.Warnings = data.warnings()
+		}
+		html, err := RenderStandingsHTML(standings)
+		if err != nil {
+			return "", fmt.Errorf("rendering standings HTML: %w", err)
+		}
+		docs = append(docs, html)
+	}
+	return combineHTMLDocuments(docs), nil
+}
+
+// seasonPageHTML renders the season so far page, with a section per club for
+// a combined newsletter. The load warnings head the page if withWarnings is
+// set.
+func seasonPageHTML(data *PreparedData, withWarnings bool) (string, error) {
+	var docs []string
+	for _, s := range data.sections() {
+		if !s.hasSeason() {
+			continue
+		}
+		season := s.buildSeasonDisplay()
+		if withWarnings && len(docs) == 0 {
+			season.Warnings = data.warnings()
+		}
+		html, err := RenderSeasonHTML(season)
+		if err != nil {
+			return "", fmt.Errorf("rendering season HTML: %w", err)
+		}
+		docs = append(docs, html)
+	}
+	return combineHTMLDocuments(docs), nil
+}
@@ -17,11 +17,10 @@ func (f *JPEGFormatter) FormatRecent(data *PreparedData, cfg Config) error {
 		return nil
 	}
 
-	recent := data.buildRecentDisplay(cfg)
-	slog.Info("rendering recent results", "rows", len(recent.Rows))
-	html, err := RenderRecentResultsHTML(recent)
+	slog.Info("rendering recent results", "clubs", len(data.sections()))
+	html, err := recentPageHTML(data, cfg, false)
 	if err != nil {
-		return fmt.Errorf("rendering recent results HTML: %w", err)
+		return err
 	}
 	slog.Info("capturing recent results screenshot")
 	jpeg, err := renderHTMLToJPEG(html, 90)
@@ -46,11 +45,10 @@ func (f *JPEGFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 		return nil
 	}
 
-	upcoming := data.buildUpcomingDisplay(cfg)
-	slog.Info("rendering upcoming matches", "days", len(upcoming.Days))
-	html, err := RenderUpcomingMatchesHTML(upcoming)
+	slog.Info("rendering upcoming matches", "clubs", len(data.sections()))
+	html, err := upcomingPageHTML(data, cfg, false)
 	if err != nil {
-		return fmt.Errorf("rendering upcoming matches HTML: %w", err)
+		return err
 	}
 	slog.Info("capturing upcoming matches screenshot")
 	jpeg, err := renderHTMLToJPEG(html, 90)
@@ -69,3 +67,59 @@ func (f *JPEGFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 
 	return nil
 }
+
+func (f *JPEGFormatter) FormatStandings(data *PreparedData, cfg Config) error {
+	if !data.hasStandings() {
+		return nil
+	}
+
+	slog.Info("rendering standings", "flights", len(data.standings()))
+	html, err := standingsPageHTML(data, false)
+	if err != nil {
+		return err
+	}
+	slog.Info("capturing standings screenshot")
+	jpeg, err := renderHTMLToJPEG(html, 90)
+	if err != nil {
+		return fmt.Errorf("rendering standings JPEG: %w", err)
+	}
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "standings", "jpg"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, jpeg, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	slog.Info("wrote standings", "path", path, "size_bytes", len(jpeg))
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+
+	return nil
+}
+
+func (f *JPEGFormatter) FormatSeason(data *PreparedData, cfg Config) error {
+	if !data.hasSeason() {
+		return nil
+	}
+
+	slog.Info("rendering season so far", "teams", len(data.season()))
+	html, err := seasonPageHTML(data, false)
+	if err != nil {
+		return err
+	}
+	slog.Info("capturing season screenshot")
+	jpeg, err := renderHTMLToJPEG(html, 90)
+	if err != nil {
+		return fmt.Errorf("rendering season JPEG: %w", err)
+	}
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "season", "jpg"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, jpeg, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	slog.Info("wrote season so far", "path", path, "size_bytes", len(jpeg))
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+
+	return nil
+}
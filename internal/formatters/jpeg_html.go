@@ -2,10 +2,8 @@ package formatters
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"html/template"
-	"io"
 	"sort"
 	"time"
 
@@ -16,6 +14,11 @@ type RecentResultsData struct {
 	OrgShortName string
 	Rows         []ResultRow
 	Footnotes    []string
+	// Highlights are the captions shown in a box above the results.
+	Highlights []string
+	// Warnings is shown as a banner in HTML output; it is left empty for
+	// JPEG images, which are meant for sharing.
+	Warnings []string
 }
 
 type ResultRow struct {
@@ -39,6 +42,7 @@ type UpcomingMatchesData struct {
 	Days         []CalendarDay
 	MaxSlots     int
 	Footnotes    []string
+	Warnings     []string
 }
 
 type CalendarDay struct {
@@ -53,31 +57,63 @@ type CalendarMatch struct {
 	LocatorEmoji    string
 	FootnoteMark    string
 	Time            string
+	Courts          string
 	GenderEmoji     string
 	Level           string
 	TeamSuperscript template.HTML
 	DaytimeEmoji    string
 	OpponentName    string
 	Tag             string
+	Rescheduled     string
 }
 
 func isWeekend(d time.Weekday) bool {
 	return d == time.Saturday || d == time.Sunday
 }
 
+// resolveTeams returns which of the match's teams is ours and which the
+// opponent. A team whose organization failed to load is only ours if it is
+// an extra team.
 func resolveTeams(m usta.Match, org *usta.Organization) (ourTeam, opponent *usta.Team, isHome bool) {
-	ctx := context.Background()
-	m.HomeTeam.LoadOrganization(ctx)
-	m.VisitingTeam.LoadOrganization(ctx)
-
-	if m.HomeTeam.Organization.Equals(org) || m.HomeTeam.Extra {
+	homeOurs := m.HomeTeam.Organization.Equals(org) || m.HomeTeam.Extra
+	// With a team filter, the home team may be one of ours the newsletter
+	// leaves out, playing one it covers.
+	if homeOurs && !org.HasTeam(m.HomeTeam.ID) && org.HasTeam(m.VisitingTeam.ID) {
+		homeOurs = false
+	}
+	if homeOurs {
 		return m.HomeTeam, m.VisitingTeam, true
 	}
 	return m.VisitingTeam, m.HomeTeam, false
 }
 
-func opponentDisplayName(names *OrgNames, reader io.Reader, writer io.Writer, org *usta.Organization) string {
-	return names.Resolve(reader, writer, org.Name)
+// opponentDisplayName returns the display name of the opponent's club, or
+// the team's own name if its club couldn't be loaded.
+func opponentDisplayName(names *OrgNames, opponent *usta.Team) string {
+	if opponent.Organization == nil {
+		return opponent.Name
+	}
+	return names.Name(opponent.Organization.Name)
+}
+
+// matchOpponentName returns how to show a match's opponent: the opposing
+// club's display name or, in a club derby, our other team's label, e.g.
+// "ASRC 👭3.5B".
+func matchOpponentName(m usta.Match, org *usta.Organization, opponent *usta.Team, names *OrgNames) string {
+	if m.Derby {
+		return org.ShortName() + " " + teamLabel(org, opponent)
+	}
+	return opponentDisplayName(names, opponent)
+}
+
+// ourTeamWon reports whether ourTeam won the match. In a club derby both
+// teams are ours, so only ourTeam itself counts.
+func ourTeamWon(m usta.Match, ourTeam *usta.Team) bool {
+	w := m.Outcome.WinningTeam
+	if m.Derby {
+		return w.ID == ourTeam.ID
+	}
+	return w.Organization.Equals(ourTeam.Organization) || w == ourTeam
 }
 
 func teamSuperscript(suffix string) template.HTML {
@@ -88,7 +124,7 @@ func teamSuperscript(suffix string) template.HTML {
 }
 
 // suffixForTeam returns the team suffix letter only when another org team
-// shares the same name; otherwise returns "".
+// shares the same name and label; otherwise returns "".
 func suffixForTeam(org *usta.Organization, t *usta.Team) string {
 	d := t.Display()
 	if d.TeamSuffix == "" {
@@ -98,7 +134,7 @@ func suffixForTeam(org *usta.Organization, t *usta.Team) string {
 		if ot.ID == t.ID {
 			continue
 		}
-		if ot.Name == t.Name {
+		if ot.Name == t.Name && ot.Display().Label() == d.Label() {
 			return d.TeamSuffix
 		}
 	}
@@ -123,7 +159,17 @@ func matchTypeTag(mt MatchType) string {
 	}
 }
 
-func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, names *OrgNames, reader io.Reader, writer io.Writer) RecentResultsData {
+// matchTag returns the tag shown above a match: its type if it isn't a
+// regular-season match, otherwise "club derby" for a match between two of our
+// teams.
+func matchTag(mt MatchType, derby bool) string {
+	if tag := matchTypeTag(mt); tag != "" || !derby {
+		return tag
+	}
+	return "club derby"
+}
+
+func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, names *OrgNames) RecentResultsData {
 	data := RecentResultsData{
 		OrgShortName: org.ShortName(),
 	}
@@ -136,8 +182,6 @@ func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, na
 		ourTeam, opponent, isHome := resolveTeams(m, org)
 		d := ourTeam.Display()
 
-		opponent.LoadOrganization(context.Background())
-
 		dayKey := m.Date.Format("2006-01-02")
 		showLabel := dayKey != currentDay
 		if showLabel {
@@ -146,12 +190,12 @@ func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, na
 
 		row := ResultRow{
 			GenderEmoji:     d.GenderEmoji(),
-			Level:           d.Level,
+			Level:           d.Label(),
 			TeamSuperscript: teamSuperscript(suffixForTeam(org, ourTeam)),
 			DaytimeEmoji:    d.DaytimeEmoji(),
 			LocatorEmoji:    locationEmoji(isHome),
-			OpponentName:    opponentDisplayName(names, reader, writer, opponent.Organization),
-			Tag:             matchTypeTag(am.Annotation.MatchType),
+			OpponentName:    matchOpponentName(m, org, opponent, names),
+			Tag:             matchTag(am.Annotation.MatchType, m.Derby),
 			IsWeekend:       isWeekend(m.Date.Weekday()),
 		}
 
@@ -175,13 +219,8 @@ func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, na
 				data.Footnotes = append(data.Footnotes, am.Annotation.Footnote)
 			}
 		} else if m.Outcome.WinningTeam != nil {
-			m.Outcome.WinningTeam.LoadOrganization(context.Background())
-			if m.Outcome.WinningTeam.Organization.Equals(ourTeam.Organization) || m.Outcome.WinningTeam == ourTeam {
-				row.IsWin = true
-				row.OutcomeText = fmt.Sprintf("won %d-%d", m.Outcome.WinnerPoints, m.Outcome.LoserPoints)
-			} else {
-				row.OutcomeText = fmt.Sprintf("lost %d-%d", m.Outcome.LoserPoints, m.Outcome.WinnerPoints)
-			}
+			row.IsWin = ourTeamWon(m, ourTeam)
+			row.OutcomeText = outcomeText(m.Outcome, row.IsWin, "-")
 		}
 
 		data.Rows = append(data.Rows, row)
@@ -190,7 +229,7 @@ func BuildRecentResultsData(org *usta.Organization, matches []AnnotatedMatch, na
 	return data
 }
 
-func BuildUpcomingMatchesData(org *usta.Organization, matches []usta.Match, names *OrgNames, locationOverrides map[int]string, reader io.Reader, writer io.Writer) UpcomingMatchesData {
+func BuildUpcomingMatchesData(org *usta.Organization, matches []usta.Match, names *OrgNames, locationOverrides map[int]string) UpcomingMatchesData {
 	data := UpcomingMatchesData{
 		OrgShortName: org.ShortName(),
 	}
@@ -236,16 +275,21 @@ func BuildUpcomingMatchesData(org *usta.Organization, matches []usta.Match, name
 
 		ourTeam, opponent, isHome := resolveTeams(m, org)
 		d := ourTeam.Display()
-		opponent.LoadOrganization(context.Background())
 
 		cm := CalendarMatch{
 			LocatorEmoji:    locationEmoji(isHome),
 			Time:            formatMatchTime(m.Date),
+			Courts:          scheduleCourts(m.Schedule),
 			GenderEmoji:     d.GenderEmoji(),
-			Level:           d.Level,
+			Level:           d.Label(),
 			TeamSuperscript: teamSuperscript(suffixForTeam(org, ourTeam)),
 			DaytimeEmoji:    d.DaytimeEmoji(),
-			OpponentName:    opponentDisplayName(names, reader, writer, opponent.Organization),
+			OpponentName:    matchOpponentName(m, org, opponent, names),
+			Rescheduled:     rescheduledNote(m),
+		}
+
+		if times := scheduleTimes(m.Schedule); times != "" {
+			cm.Time = times
 		}
 
 		if loc, ok := locationOverrides[i]; ok {
@@ -366,11 +410,17 @@ const recentResultsHTML = `<!DOCTYPE html>
   .footnotes { font-size: 16px; font-style: italic; text-align: right; margin-top: 10px; color: #666; }
   .team-col { font-size: 22px; font-weight: bold; }
   .opponent { font-weight: bold; }
+  .warning { background-color: #fff3cd; border: 1px solid #e0a800; border-radius: 4px; padding: 6px 10px; margin-bottom: 12px; font-family: sans-serif; font-size: 14px; white-space: normal; max-width: 640px; }
+  .highlights { border: 2px solid #f9a825; border-radius: 8px; background-color: #fffde7; padding: 6px 14px; margin-bottom: 14px; font-size: 18px; }
+  .highlights-title { font-weight: bold; text-align: center; }
+  .highlights ul { margin: 4px 0; padding-left: 20px; }
 </style>
 </head>
 <body>
+  {{if .Warnings}}<div class="warning">⚠️ Some USTA data failed to load; this newsletter may be incomplete.<ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul></div>{{end}}
   <div class="title">🏆🎾 {{.OrgShortName}} plays USTA league 🎾🏆</div>
   <div class="subtitle">Recent Results</div>
+  {{if .Highlights}}<div class="highlights"><div class="highlights-title">⭐ Highlights ⭐</div><ul>{{range .Highlights}}<li>{{.}}</li>{{end}}</ul></div>{{end}}
   <table>
     {{range .Rows}}
     <tr>
@@ -434,12 +484,16 @@ const upcomingMatchesHTML = `<!DOCTYPE html>
   .match-entry { margin-bottom: 2px; }
   .match-time { font-weight: bold; }
   .match-opponent { font-weight: bold; }
+  .match-courts { font-size: 14px; color: #666; }
   .tag { background-color: yellow; padding: 1px 4px; border-radius: 4px; font-style: italic; font-size: 14px; }
+  .rescheduled { font-size: 13px; font-style: italic; color: #b35900; }
   .footnotes { font-size: 14px; font-style: italic; text-align: right; margin-top: 10px; color: #666; }
   .empty-cell { }
+  .warning { background-color: #fff3cd; border: 1px solid #e0a800; border-radius: 4px; padding: 6px 10px; margin-bottom: 12px; font-family: sans-serif; font-size: 14px; white-space: normal; max-width: 640px; }
 </style>
 </head>
 <body>
+  {{if .Warnings}}<div class="warning">⚠️ Some USTA data failed to load; this newsletter may be incomplete.<ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul></div>{{end}}
   <div class="title">🏆🎾 {{.OrgShortName}} plays USTA league 🎾🏆</div>
   <div class="subtitle">Upcoming Matches</div>
   <table>
@@ -454,9 +508,10 @@ const upcomingMatchesHTML = `<!DOCTYPE html>
         {{if not .Empty}}
         <div class="match-entry">
           {{if .Tag}}<span class="tag" style="display:block; text-align:center">{{.Tag}}</span>{{end}}
-          {{.LocatorEmoji}}{{.FootnoteMark}} <span class="match-time">{{.Time}}</span><br>
+          {{.LocatorEmoji}}{{.FootnoteMark}} <span class="match-time">{{.Time}}</span>{{if .Courts}} <span class="match-courts">{{.Courts}}</span>{{end}}<br>
           {{.GenderEmoji}} {{.Level}}{{.TeamSuperscript}}{{.DaytimeEmoji}}<br>
           <span class="match-opponent">{{.OpponentName}}</span>
+          {{if .Rescheduled}}<br><span class="rescheduled">{{.Rescheduled}}</span>{{end}}
         </div>
         {{end}}
         {{end}}
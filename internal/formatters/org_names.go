@@ -45,6 +45,15 @@ func (on *OrgNames) Save() error {
 	return os.WriteFile(orgNamesFile, data, 0644)
 }
 
+// Name returns the display name for the USTA organization name ustaName, or
+// ustaName itself if it has none yet. Unlike Resolve it never prompts.
+func (on *OrgNames) Name(ustaName string) string {
+	if friendly, ok := on.names[strings.ToUpper(ustaName)]; ok {
+		return friendly
+	}
+	return ustaName
+}
+
 func (on *OrgNames) Resolve(reader io.Reader, writer io.Writer, ustaName string) string {
 	key := strings.ToUpper(ustaName)
 	if friendly, ok := on.names[key]; ok {
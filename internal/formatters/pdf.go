@@ -33,34 +33,41 @@ func (p *PDFFormatter) FormatRecent(data *PreparedData, cfg Config) error {
 		})
 	})
 
-	if data.DataFile != nil {
-		for i, rec := range data.DataFile.PastMatches {
-			i := i
-			rec := rec
-			setRowColor(i, m)
-			date := dataFileDateDisplay(rec.Date)
-			teamStr := data.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript
-			outcome := consoleOutcome(rec)
-			locOpponent := consoleLocOpponent(rec.IsHome, rec.Opponent)
-			m.Row(8, func() {
-				m.Col(2, func() { m.Text(" "+date, cellTextProps) })
-				m.Col(4, func() { m.Text(teamStr, cellTextProps) })
-				m.Col(1, func() { m.Text(outcome, cellTextProps) })
-				m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
-			})
+	for _, s := range data.sections() {
+		if !s.hasPastMatches() {
+			continue
 		}
-	} else {
-		for i, am := range data.PastMatches {
-			i := i
-			am := am
-			setRowColor(i, m)
-			date, first, outcome, locOpponent := formatAnnotatedMatch(am, data.Org, data.OrgNames, cfg.Reader, cfg.Writer)
-			m.Row(8, func() {
-				m.Col(2, func() { m.Text(" "+date, cellTextProps) })
-				m.Col(4, func() { m.Text(first, cellTextProps) })
-				m.Col(1, func() { m.Text(outcome, cellTextProps) })
-				m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
-			})
+		pdfClubRow(m, data, s)
+		pdfHighlightRows(m, s)
+		if s.DataFile != nil {
+			for i, rec := range s.DataFile.PastMatches {
+				i := i
+				rec := rec
+				setRowColor(i, m)
+				date := dataFileDateDisplay(rec.Date)
+				teamStr := s.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript
+				outcome := consoleOutcome(rec)
+				locOpponent := consoleLocOpponent(rec.IsHome, rec.Opponent)
+				m.Row(8, func() {
+					m.Col(2, func() { m.Text(" "+date, cellTextProps) })
+					m.Col(4, func() { m.Text(teamStr, cellTextProps) })
+					m.Col(1, func() { m.Text(outcome, cellTextProps) })
+					m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+				})
+			}
+		} else {
+			for i, am := range s.PastMatches {
+				i := i
+				am := am
+				setRowColor(i, m)
+				date, first, outcome, locOpponent := formatAnnotatedMatch(am, s.Org, s.OrgNames)
+				m.Row(8, func() {
+					m.Col(2, func() { m.Text(" "+date, cellTextProps) })
+					m.Col(4, func() { m.Text(first, cellTextProps) })
+					m.Col(1, func() { m.Text(outcome, cellTextProps) })
+					m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+				})
+			}
 		}
 	}
 
@@ -93,41 +100,171 @@ func (p *PDFFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 		})
 	})
 
-	if data.DataFile != nil {
-		for i, rec := range data.DataFile.FutureMatches {
-			i := i
-			rec := rec
-			setRowColor(i, m)
-			date := dataFileDateDisplay(rec.Date)
-			if rec.Time != "" {
-				date += " " + dataFileMatchTime(rec.Time)
+	for _, s := range data.sections() {
+		if !s.hasUpcomingMatches() {
+			continue
+		}
+		pdfClubRow(m, data, s)
+		if s.DataFile != nil {
+			for i, rec := range s.DataFile.FutureMatches {
+				i := i
+				rec := rec
+				setRowColor(i, m)
+				date := dataFileDateDisplay(rec.Date)
+				if rec.Time != "" {
+					date += " " + rec.displayTime()
+				}
+				teamStr := s.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript
+				locOpponent := consoleLocOpponent(rec.IsHome, rec.Opponent)
+				if rec.LocationNote != "" {
+					locOpponent += " (at " + rec.LocationNote + ")"
+				}
+				if rec.Rescheduled != "" {
+					locOpponent += " (" + rec.Rescheduled + ")"
+				}
+				m.Row(8, func() {
+					m.Col(3, func() { m.Text(" "+date, cellTextProps) })
+					m.Col(4, func() { m.Text(teamStr, cellTextProps) })
+					m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+				})
 			}
-			teamStr := data.DataFile.OrgShortName + " " + rec.GenderEmoji + rec.Level + rec.Superscript
-			locOpponent := consoleLocOpponent(rec.IsHome, rec.Opponent)
-			if rec.LocationNote != "" {
-				locOpponent += " (at " + rec.LocationNote + ")"
+		} else {
+			for i, match := range s.FutureMatches {
+				i := i
+				match := match
+				setRowColor(i, m)
+				date, first, _, locOpponent := formatFutureMatch(match, s.Org, s.OrgNames)
+				if note := rescheduledNote(match); note != "" {
+					locOpponent += " (" + note + ")"
+				}
+				m.Row(8, func() {
+					m.Col(3, func() { m.Text(" "+date, cellTextProps) })
+					m.Col(4, func() { m.Text(first, cellTextProps) })
+					m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+				})
+			}
+		}
+	}
+
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "upcoming", "pdf"))
+	if err != nil {
+		return err
+	}
+	if err := m.OutputFileAndClose(path); err != nil {
+		return err
+	}
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+	return nil
+}
+
+func (p *PDFFormatter) FormatStandings(data *PreparedData, cfg Config) error {
+	if !data.hasStandings() {
+		return nil
+	}
+
+	m := pdf.NewMaroto(consts.Portrait, consts.A4)
+	cellTextProps := props.Text{Size: 8, Top: 2}
+	headerTextProps := props.Text{Size: 8, Top: 2, Style: consts.BoldItalic}
+
+	m.Row(10, func() {
+		m.Col(12, func() {
+			m.Text("Standings", props.Text{
+				Top:   3,
+				Style: consts.Bold,
+				Align: consts.Center,
+			})
+		})
+	})
+
+	for _, f := range data.standings() {
+		title := f.Teams + " (" + f.Flight + ")"
+		m.SetBackgroundColor(color.NewWhite())
+		m.Row(9, func() {
+			m.Col(12, func() { m.Text(title, props.Text{Size: 9, Top: 3, Style: consts.Bold}) })
+		})
+		m.Row(7, func() {
+			m.Col(1, func() { m.Text(" #", headerTextProps) })
+			m.Col(6, func() { m.Text("Team", headerTextProps) })
+			m.Col(2, func() { m.Text("W-L", headerTextProps) })
+			m.Col(2, func() { m.Text("Ind. %", headerTextProps) })
+			m.Col(1, func() { m.Text("Left", headerTextProps) })
+		})
+		for i, r := range f.Rows {
+			r := r
+			setRowColor(i, m)
+			textProps := cellTextProps
+			if r.IsOurs {
+				textProps.Style = consts.Bold
 			}
-			m.Row(8, func() {
-				m.Col(3, func() { m.Text(" "+date, cellTextProps) })
-				m.Col(4, func() { m.Text(teamStr, cellTextProps) })
-				m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+			m.Row(7, func() {
+				m.Col(1, func() { m.Text(fmt.Sprintf(" %d", r.Position), textProps) })
+				m.Col(6, func() { m.Text(r.Team, textProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%d-%d", r.Wins, r.Losses), textProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%.0f%%", r.IndividualWinPct), textProps) })
+				m.Col(1, func() { m.Text(fmt.Sprint(r.Remaining), textProps) })
 			})
 		}
-	} else {
-		for i, match := range data.FutureMatches {
-			i := i
-			match := match
+	}
+
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "standings", "pdf"))
+	if err != nil {
+		return err
+	}
+	if err := m.OutputFileAndClose(path); err != nil {
+		return err
+	}
+	fmt.Fprintln(cfg.Writer, "Wrote", path)
+	return nil
+}
+
+func (p *PDFFormatter) FormatSeason(data *PreparedData, cfg Config) error {
+	if !data.hasSeason() {
+		return nil
+	}
+
+	m := pdf.NewMaroto(consts.Portrait, consts.A4)
+	cellTextProps := props.Text{Size: 8, Top: 2}
+	headerTextProps := props.Text{Size: 8, Top: 2, Style: consts.BoldItalic}
+
+	m.Row(10, func() {
+		m.Col(12, func() {
+			m.Text("Season so far", props.Text{
+				Top:   3,
+				Style: consts.Bold,
+				Align: consts.Center,
+			})
+		})
+	})
+
+	for _, s := range data.sections() {
+		if !s.hasSeason() {
+			continue
+		}
+		pdfClubRow(m, data, s)
+		m.SetBackgroundColor(color.NewWhite())
+		m.Row(7, func() {
+			m.Col(3, func() { m.Text(" Team", headerTextProps) })
+			m.Col(2, func() { m.Text("W-L", headerTextProps) })
+			m.Col(2, func() { m.Text("Points", headerTextProps) })
+			m.Col(2, func() { m.Text("Home", headerTextProps) })
+			m.Col(2, func() { m.Text("Away", headerTextProps) })
+			m.Col(1, func() { m.Text("Streak", headerTextProps) })
+		})
+		for i, r := range s.season() {
+			r := r
 			setRowColor(i, m)
-			date, first, _, locOpponent := formatFutureMatch(match, data.Org, data.OrgNames, cfg.Reader, cfg.Writer)
-			m.Row(8, func() {
-				m.Col(3, func() { m.Text(" "+date, cellTextProps) })
-				m.Col(4, func() { m.Text(first, cellTextProps) })
-				m.Col(5, func() { m.Text(locOpponent, cellTextProps) })
+			m.Row(7, func() {
+				m.Col(3, func() { m.Text(" "+r.Team, cellTextProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%d-%d", r.Wins, r.Losses), cellTextProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%d-%d", r.PointsWon, r.PointsLost), cellTextProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%d-%d", r.HomeWins, r.HomeLosses), cellTextProps) })
+				m.Col(2, func() { m.Text(fmt.Sprintf("%d-%d", r.AwayWins, r.AwayLosses), cellTextProps) })
+				m.Col(1, func() { m.Text(r.StreakText(), cellTextProps) })
 			})
 		}
 	}
 
-	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "upcoming", "pdf"))
+	path, err := OutputPath(cfg.OutputDir, OutputFilename(data.orgShortName(), "season", "pdf"))
 	if err != nil {
 		return err
 	}
@@ -138,6 +275,39 @@ func (p *PDFFormatter) FormatUpcoming(data *PreparedData, cfg Config) error {
 	return nil
 }
 
+// pdfHighlightRows adds a Highlights box listing the highlights for club s,
+// if any.
+func pdfHighlightRows(m pdf.Maroto, s *PreparedData) {
+	captions := s.highlightCaptions()
+	if len(captions) == 0 {
+		return
+	}
+	m.SetBackgroundColor(color.Color{Red: 255, Green: 253, Blue: 231})
+	m.Row(8, func() {
+		m.Col(12, func() { m.Text(" Highlights", props.Text{Size: 9, Top: 2, Style: consts.Bold}) })
+	})
+	for _, c := range captions {
+		c := c
+		m.Row(6, func() {
+			m.Col(12, func() { m.Text("   - "+c, props.Text{Size: 8, Top: 1}) })
+		})
+	}
+	m.SetBackgroundColor(color.NewWhite())
+	m.Row(3, func() {})
+}
+
+// pdfClubRow adds a heading row naming the club s in a combined newsletter.
+func pdfClubRow(m pdf.Maroto, data, s *PreparedData) {
+	if !data.combined() {
+		return
+	}
+	name := s.orgShortName()
+	m.SetBackgroundColor(color.NewWhite())
+	m.Row(9, func() {
+		m.Col(12, func() { m.Text(name, props.Text{Size: 9, Top: 3, Style: consts.Bold}) })
+	})
+}
+
 func setRowColor(rowIndex int, m pdf.Maroto) {
 	lightGrayColor := color.Color{Red: 200, Green: 200, Blue: 200}
 	whiteColor := color.NewWhite()
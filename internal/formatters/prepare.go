@@ -2,6 +2,7 @@ package formatters
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -19,21 +20,99 @@ type PreparedData struct {
 	FutureMatches     []usta.Match
 	OrgNames          *OrgNames
 	LocationOverrides map[int]string
+	Standings         []FlightStandingsRecord
+	Season            []SeasonRecord
+	Highlights        []HighlightRecord
+	// Warnings describes data that failed to load from USTA; non-empty means
+	// the newsletter may be incomplete.
+	Warnings []string
 
 	// Non-nil when loaded from an existing data file instead of USTA.
 	DataFile *DataFile
+
+	// Sections is set for a combined newsletter of several organizations,
+	// one per club; the other fields are then unset. See NewCombined.
+	Sections []*PreparedData
+
+	// consoleWarned is set once the console has shown the load warnings, so
+	// a run shows them only once, above its first section.
+	consoleWarned bool
 }
 
 // orgShortName returns the org short name from either live data or the data file.
 func (d *PreparedData) orgShortName() string {
+	if d.combined() {
+		names := make([]string, len(d.Sections))
+		for i, s := range d.Sections {
+			names[i] = s.orgShortName()
+		}
+		return strings.Join(names, "-")
+	}
 	if d.DataFile != nil {
 		return d.DataFile.OrgShortName
 	}
 	return d.Org.ShortName()
 }
 
+// warnings returns the load warnings from either live data or the data file.
+func (d *PreparedData) warnings() []string {
+	if d.combined() {
+		// Every club's data shares one load report, so only list each
+		// warning once.
+		var warnings []string
+		seen := map[string]bool{}
+		for _, s := range d.Sections {
+			for _, w := range s.warnings() {
+				if !seen[w] {
+					seen[w] = true
+					warnings = append(warnings, w)
+				}
+			}
+		}
+		return warnings
+	}
+	if d.DataFile != nil {
+		return d.DataFile.Warnings
+	}
+	return d.Warnings
+}
+
+// standings returns the flight standings from either live data or the data file.
+func (d *PreparedData) standings() []FlightStandingsRecord {
+	if d.combined() {
+		var standings []FlightStandingsRecord
+		for _, s := range d.Sections {
+			standings = append(standings, s.standings()...)
+		}
+		return standings
+	}
+	if d.DataFile != nil {
+		return d.DataFile.Standings
+	}
+	return d.Standings
+}
+
+// season returns the club teams' season records from either live data or
+// the data file.
+func (d *PreparedData) season() []SeasonRecord {
+	if d.combined() {
+		var season []SeasonRecord
+		for _, s := range d.Sections {
+			season = append(season, s.season()...)
+		}
+		return season
+	}
+	if d.DataFile != nil {
+		return d.DataFile.Season
+	}
+	return d.Season
+}
+
 // hasPastMatches reports whether there are any past matches to render.
 func (d *PreparedData) hasPastMatches() bool {
+	if d.combined() {
+		return d.anySection((*PreparedData).hasPastMatches)
+	}
 	if d.DataFile != nil {
 		return len(d.DataFile.PastMatches) > 0
 	}
@@ -42,6 +121,9 @@ func (d *PreparedData) hasPastMatches() bool {
 
 // hasUpcomingMatches reports whether there are any upcoming matches to render.
 func (d *PreparedData) hasUpcomingMatches() bool {
+	if d.combined() {
+		return d.anySection((*PreparedData).hasUpcomingMatches)
+	}
 	if d.DataFile != nil {
 		return len(d.DataFile.FutureMatches) > 0
 	}
@@ -52,9 +134,13 @@ func (d *PreparedData) hasUpcomingMatches() bool {
 // Uses the data file when available, otherwise builds from live match data.
 func (d *PreparedData) buildRecentDisplay(cfg Config) RecentResultsData {
 	if d.DataFile != nil {
-		return d.DataFile.ToRecentResultsData()
+		recent := d.DataFile.ToRecentResultsData()
+		recent.Highlights = d.highlightCaptions()
+		return recent
 	}
-	return BuildRecentResultsData(d.Org, d.PastMatches, d.OrgNames, cfg.Reader, cfg.Writer)
+	recent := BuildRecentResultsData(d.Org, d.PastMatches, d.OrgNames)
+	recent.Highlights = d.highlightCaptions()
+	return recent
 }
 
 // buildUpcomingDisplay returns display-ready upcoming matches data.
@@ -63,10 +149,56 @@ func (d *PreparedData) buildUpcomingDisplay(cfg Config) UpcomingMatchesData {
 	if d.DataFile != nil {
 		return d.DataFile.ToUpcomingMatchesData()
 	}
-	return BuildUpcomingMatchesData(d.Org, d.FutureMatches, d.OrgNames, d.LocationOverrides, cfg.Reader, cfg.Writer)
+	return BuildUpcomingMatchesData(d.Org, d.FutureMatches, d.OrgNames, d.LocationOverrides)
+}
+
+// Prepare prepares the newsletter data for the snapshot's first
+// organization.
+func Prepare(snap *core.Snapshot, cfg Config) (*PreparedData, error) {
+	return prepareOrganization(snap, snap.Organization(), nil, cfg)
+}
+
+// PrepareAll prepares the newsletter data for each of the snapshot's
+// organizations, in order. With more than one organization, each is saved to
+// and loaded from its own data file; see OrganizationDataFilePath.
+func PrepareAll(snap *core.Snapshot, cfg Config) ([]*PreparedData, error) {
+	names, err := loadOrgNames()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := snap.OrganizationIDs()
+	orgs := snap.Organizations()
+	sections := make([]*PreparedData, len(ids))
+	for i, id := range ids {
+		var org *usta.Organization
+		if i < len(orgs) {
+			org = orgs[i]
+		}
+		c := cfg
+		c.OrganizationID = id
+		c.DataFilePath = OrganizationDataFilePath(cfg.DataFilePath, id, len(ids))
+		if sections[i], err = prepareOrganization(snap, org, names, c); err != nil {
+			return nil, fmt.Errorf("organization %d: %w", id, err)
+		}
+	}
+	return sections, nil
 }
 
-func Prepare(n *core.Newsletter, cfg Config) (*PreparedData, error) {
+// OrganizationDataFilePath returns the data file path for one of count
+// organizations: path itself for a single organization, otherwise path with
+// the organization ID added, e.g. "data_225.json".
+func OrganizationDataFilePath(path string, orgID, count int) string {
+	if path == "" || count <= 1 {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(path, ext), orgID, ext)
+}
+
+// prepareOrganization prepares the newsletter data for org, loading the org
+// display names if names is nil.
+func prepareOrganization(snap *core.Snapshot, org *usta.Organization, names *OrgNames, cfg Config) (*PreparedData, error) {
 	// If a data file already exists, load it and skip fetching from USTA.
 	if cfg.DataFilePath != "" {
 		if _, err := os.Stat(cfg.DataFilePath); err == nil {
@@ -80,21 +212,28 @@ func Prepare(n *core.Newsletter, cfg Config) (*PreparedData, error) {
 		}
 	}
 
-	org := n.Organization()
 	pastMatches, futureMatches := org.Matches(cfg.PastDuration, cfg.FutureDuration, cfg.BoundaryDate)
 	slog.Info("filtered matches", "past", len(pastMatches), "future", len(futureMatches))
+	if cfg.DisplayLocation != nil {
+		toDisplayZone(pastMatches, cfg.DisplayLocation)
+		toDisplayZone(futureMatches, cfg.DisplayLocation)
+	}
 
 	annotated := make([]AnnotatedMatch, len(pastMatches))
 	for i, m := range pastMatches {
-		annotated[i] = AnnotatedMatch{Match: m}
+		annotated[i] = AnnotatedMatch{Match: m, Annotation: matchAnnotation(m)}
 	}
 
-	slog.Info("loading org display names", "file", orgNamesFile)
-	names, err := LoadOrgNames()
-	if err != nil {
-		return nil, fmt.Errorf("loading org names: %w", err)
+	if names == nil {
+		var err error
+		if names, err = loadOrgNames(); err != nil {
+			return nil, err
+		}
 	}
-	slog.Info("loaded org display names", "count", len(names.names))
+
+	// Ask for any display names we don't know yet up front, so that
+	// formatting never has to.
+	resolveOpponentNames(cfg.Reader, cfg.Writer, org, names, pastMatches, futureMatches)
 
 	PromptNoOutcomeMatches(cfg.Reader, cfg.Writer, annotated, org, names)
 	PromptPlayoffMatches(cfg.Reader, cfg.Writer, annotated, org, names)
@@ -106,11 +245,17 @@ func Prepare(n *core.Newsletter, cfg Config) (*PreparedData, error) {
 		FutureMatches:     futureMatches,
 		OrgNames:          names,
 		LocationOverrides: locationOverrides,
+		Standings:         BuildStandings(org),
+		Season:            BuildSeason(org, cfg.BoundaryDate),
+		Highlights:        BuildHighlights(org, names, cfg.PastDuration, cfg.BoundaryDate),
+	}
+	for _, f := range snap.Failures() {
+		data.Warnings = append(data.Warnings, f.String())
 	}
 
 	// Save intermediate data file so it can be edited and re-used.
 	if cfg.DataFilePath != "" {
-		df := NewDataFile(data, cfg.Reader, cfg.Writer)
+		df := NewDataFile(data)
 		if err := df.Save(cfg.DataFilePath); err != nil {
 			slog.Warn("failed to save data file", "path", cfg.DataFilePath, "error", err)
 		} else {
@@ -123,6 +268,14 @@ func Prepare(n *core.Newsletter, cfg Config) (*PreparedData, error) {
 }
 
 func (d *PreparedData) Save() error {
+	if d.combined() {
+		for _, s := range d.Sections {
+			if err := s.Save(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	if d.OrgNames == nil {
 		return nil
 	}
@@ -132,6 +285,46 @@ func (d *PreparedData) Save() error {
 	return nil
 }
 
+// resolveOpponentNames asks for the display name of each opposing club in
+// matches that doesn't have one yet.
+func resolveOpponentNames(reader io.Reader, writer io.Writer, org *usta.Organization, names *OrgNames, matches ...[]usta.Match) {
+	for _, ms := range matches {
+		for _, m := range ms {
+			if m.Derby {
+				continue
+			}
+			_, opponent, _ := resolveTeams(m, org)
+			if opponent.Organization != nil {
+				names.Resolve(reader, writer, opponent.Organization.Name)
+			}
+		}
+	}
+}
+
+func loadOrgNames() (*OrgNames, error) {
+	slog.Info("loading org display names", "file", orgNamesFile)
+	names, err := LoadOrgNames()
+	if err != nil {
+		return nil, fmt.Errorf("loading org names: %w", err)
+	}
+	slog.Info("loaded org display names", "count", len(names.names))
+	return names, nil
+}
+
+// toDisplayZone converts the times of matches to loc. Matches without a
+// known time keep their league date, which would otherwise shift a day.
+func toDisplayZone(matches []usta.Match, loc *time.Location) {
+	for i := range matches {
+		if !matches[i].HasTime {
+			continue
+		}
+		matches[i].Date = matches[i].Date.In(loc)
+		if !matches[i].RescheduledFrom.IsZero() {
+			matches[i].RescheduledFrom = matches[i].RescheduledFrom.In(loc)
+		}
+	}
+}
+
 func OutputFilename(orgShortName, suffix, ext string) string {
 	now := time.Now()
 	return fmt.Sprintf("%s_usta_%s_%s.%s",
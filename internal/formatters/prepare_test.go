@@ -0,0 +1,55 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestToDisplayZone(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	matches := []usta.Match{
+		{Date: time.Date(2026, 4, 14, 22, 0, 0, 0, la), HasTime: true},
+		{Date: time.Date(2026, 4, 15, 0, 0, 0, 0, la)},
+	}
+	toDisplayZone(matches, ny)
+
+	require.Equal(t, time.Date(2026, 4, 15, 1, 0, 0, 0, ny), matches[0].Date)
+	require.Equal(t, "1am", formatMatchTime(matches[0].Date))
+	// Without a time, the match stays on its league date.
+	require.Equal(t, "2026-04-15", matches[1].Date.Format("2006-01-02"))
+}
+
+func TestBuildRecentResultsDataUsesKnownNamesOnly(t *testing.T) {
+	am := makeWinMatch()
+	names := &OrgNames{names: map[string]string{}}
+
+	// An unknown club keeps its USTA name rather than prompting.
+	data := BuildRecentResultsData(makeTestOrg(), []AnnotatedMatch{am}, names)
+	require.Len(t, data.Rows, 1)
+	require.Equal(t, "Almaden Valley Athletic Club", data.Rows[0].OpponentName)
+	require.True(t, data.Rows[0].IsWin)
+
+	// A team whose club couldn't be loaded is shown by its own name.
+	am.Match.VisitingTeam.Organization = nil
+	data = BuildRecentResultsData(makeTestOrg(), []AnnotatedMatch{am}, makeTestOrgNames())
+	require.Equal(t, "Adult 18+ Mens 4.0", data.Rows[0].OpponentName)
+}
+
+func TestOutcomeText(t *testing.T) {
+	played := usta.Outcome{Kind: usta.OutcomePlayed, WinnerPoints: 3, LoserPoints: 2}
+	require.Equal(t, "won 3-2", outcomeText(played, true, "-"))
+	require.Equal(t, "lost 2 - 3", outcomeText(played, false, " - "))
+
+	retired := usta.Outcome{Kind: usta.OutcomeRetired, WinnerPoints: 3, LoserPoints: 2}
+	require.Equal(t, "lost 2-3 (ret.)", outcomeText(retired, false, "-"))
+
+	require.Equal(t, "won by forfeit", outcomeText(usta.Outcome{Kind: usta.OutcomeForfeit}, true, "-"))
+	require.Equal(t, "lost by default", outcomeText(usta.Outcome{Kind: usta.OutcomeDefault, WinnerPoints: 5}, false, "-"))
+}
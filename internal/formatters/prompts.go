@@ -2,7 +2,6 @@ package formatters
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"io"
 	"regexp"
@@ -16,12 +15,11 @@ import (
 var scoreExplanationRegex = regexp.MustCompile(`^(\d+-\d+)\s+(.+)$`)
 var wonLostRegex = regexp.MustCompile(`(?i)^(won|lost|w|l)\s+(\d+)-(\d+)$`)
 
-func describeMatch(m usta.Match, org *usta.Organization, names *OrgNames, reader io.Reader, writer io.Writer) string {
+func describeMatch(m usta.Match, org *usta.Organization, names *OrgNames) string {
 	ourTeam, opponent, isHome := resolveTeams(m, org)
-	opponent.LoadOrganization(context.Background())
 
 	d := ourTeam.Display()
-	opName := opponentDisplayName(names, reader, writer, opponent.Organization)
+	opName := matchOpponentName(m, org, opponent, names)
 
 	locator := "vs"
 	if !isHome {
@@ -30,7 +28,7 @@ func describeMatch(m usta.Match, org *usta.Organization, names *OrgNames, reader
 
 	return fmt.Sprintf("%s: %s%s%s %s %s",
 		m.Date.Format("Mon 1/2"),
-		d.GenderEmoji(), d.Level, suffixForTeam(org, ourTeam),
+		d.GenderEmoji(), d.Label(), suffixForTeam(org, ourTeam),
 		locator,
 		opName,
 	)
@@ -40,11 +38,11 @@ func PromptNoOutcomeMatches(reader io.Reader, writer io.Writer, matches []Annota
 	scanner := bufio.NewScanner(reader)
 
 	for i := range matches {
-		if matches[i].Match.Outcome.WinningTeam != nil {
+		if matches[i].Match.Outcome.Decided() || matches[i].Annotation.RainedOut {
 			continue
 		}
 
-		desc := describeMatch(matches[i].Match, org, names, reader, writer)
+		desc := describeMatch(matches[i].Match, org, names)
 
 		for {
 			fmt.Fprintf(writer, "%s — no outcome recorded.\n", desc)
@@ -99,22 +97,18 @@ func PromptExtraTeamLocations(reader io.Reader, writer io.Writer, matches []usta
 	defaultLoc := org.ShortName()
 
 	for i, m := range matches {
-		ctx := context.Background()
-		m.HomeTeam.LoadOrganization(ctx)
-
 		if !m.HomeTeam.Extra {
 			continue
 		}
 
-		m.VisitingTeam.LoadOrganization(ctx)
 		ourTeam := m.HomeTeam
 		opponent := m.VisitingTeam
 		d := ourTeam.Display()
-		opName := opponentDisplayName(names, reader, writer, opponent.Organization)
+		opName := opponentDisplayName(names, opponent)
 
 		fmt.Fprintf(writer, "%s: %s%s%s vs %s (home) — Location [%s]: ",
 			m.Date.Format("Mon 1/2"),
-			d.GenderEmoji(), d.Level, suffixForTeam(org, ourTeam),
+			d.GenderEmoji(), d.Label(), suffixForTeam(org, ourTeam),
 			opName,
 			defaultLoc,
 		)
@@ -132,20 +126,26 @@ func PromptExtraTeamLocations(reader io.Reader, writer io.Writer, matches []usta
 }
 
 func matchWasPlayed(m AnnotatedMatch) bool {
-	now := time.Now()
+	now := time.Now().In(m.Match.Date.Location())
 	endOfToday := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
 	return m.Match.Date.Before(endOfToday)
 }
 
+// needsMatchType reports whether a match was played but couldn't be
+// classified as regular season, playoff or Sectionals from the USTA data.
+func needsMatchType(m AnnotatedMatch) bool {
+	return matchWasPlayed(m) && m.Match.Type == usta.MatchTypeUnknown
+}
+
 func PromptPlayoffMatches(reader io.Reader, writer io.Writer, matches []AnnotatedMatch, org *usta.Organization, names *OrgNames) {
-	hasPlayed := false
+	hasUnclassified := false
 	for _, m := range matches {
-		if matchWasPlayed(m) {
-			hasPlayed = true
+		if needsMatchType(m) {
+			hasUnclassified = true
 			break
 		}
 	}
-	if !hasPlayed {
+	if !hasUnclassified {
 		return
 	}
 
@@ -167,11 +167,11 @@ func PromptPlayoffMatches(reader io.Reader, writer io.Writer, matches []Annotate
 	}
 
 	for i := range matches {
-		if !matchWasPlayed(matches[i]) {
+		if !needsMatchType(matches[i]) {
 			continue
 		}
 
-		desc := describeMatch(matches[i].Match, org, names, reader, writer)
+		desc := describeMatch(matches[i].Match, org, names)
 
 		for {
 			fmt.Fprintf(writer, "%s — (p)layoff / (s)ectionals / (r)egular: ", desc)
@@ -240,3 +240,41 @@ func TestPromptPlayoffMatches_OnlyPromptsPastMatches(t *testing.T) {
 	require.Equal(t, Playoff, matches[1].Annotation.MatchType)
 	require.Equal(t, RegularSeason, matches[2].Annotation.MatchType)
 }
+
+func TestPromptNoOutcomeMatches_SkipsKnownNonPlayedOutcomes(t *testing.T) {
+	rainout := makeNoOutcomeMatch()
+	rainout.Match.Outcome.Kind = usta.OutcomeRainout
+	rainout.Annotation = matchAnnotation(rainout.Match)
+
+	postponed := makeNoOutcomeMatch()
+	postponed.Match.Outcome.Kind = usta.OutcomePostponed
+	postponed.Annotation = matchAnnotation(postponed.Match)
+
+	matches := []AnnotatedMatch{rainout, postponed}
+	output := &bytes.Buffer{}
+
+	PromptNoOutcomeMatches(strings.NewReader(""), output, matches, makeTestOrg(), makeTestOrgNames())
+
+	require.Empty(t, output.String())
+	require.True(t, matches[0].Annotation.RainedOut)
+	require.Equal(t, "postponed", matches[1].Annotation.Footnote)
+}
+
+func TestPromptPlayoffMatches_SkipsClassifiedMatches(t *testing.T) {
+	playoff := makeWinMatch()
+	playoff.Match.Type = usta.MatchTypePlayoff
+	playoff.Annotation = matchAnnotation(playoff.Match)
+	regular := makeWinMatch()
+	regular.Match.Type = usta.MatchTypeRegular
+	regular.Annotation = matchAnnotation(regular.Match)
+
+	matches := []AnnotatedMatch{playoff, regular}
+	input := strings.NewReader("")
+	output := &bytes.Buffer{}
+
+	PromptPlayoffMatches(input, output, matches, makeTestOrg(), makeTestOrgNames())
+
+	require.Empty(t, output.String())
+	require.Equal(t, Playoff, matches[0].Annotation.MatchType)
+	require.Equal(t, RegularSeason, matches[1].Annotation.MatchType)
+}
@@ -0,0 +1,134 @@
+package formatters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// redactedGateCode is shown in place of a gate code unless the user asks for
+// gate codes to be included.
+const redactedGateCode = "•••••"
+
+// accessCodeRegex matches a code for getting into a facility in free-form
+// schedule notes, which usta.Schedule.GateCode may not have caught, e.g.
+// "gate 24865", "access code 1234" or "keypad #4455". The code is the last
+// group.
+var accessCodeRegex = regexp.MustCompile(`(?i)\b(?:gate|door|keypad|access|entry|lock|combo|combination|pin|code)\b[^0-9\n]{0,15}?#?\s*(\d{3,})`)
+
+// redactAccessCodes returns note with any access codes in it redacted.
+func redactAccessCodes(note string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range accessCodeRegex.FindAllStringSubmatchIndex(note, -1) {
+		b.WriteString(note[last:m[2]])
+		b.WriteString(redactedGateCode)
+		last = m[3]
+	}
+	b.WriteString(note[last:])
+	return b.String()
+}
+
+// scheduleTimes returns the start times of a match whose lines start at
+// different times, e.g. "6pm (L1-2), 7:30pm (L3)" or "6:30pm & 7:45pm". It
+// returns "" if all lines start together.
+func scheduleTimes(s usta.Schedule) string {
+	if !s.Staggered() {
+		return ""
+	}
+
+	firstLines := s.LineStarts()
+	times := make([]string, len(s.Starts))
+	for i, st := range s.Starts {
+		times[i] = dataFileMatchTime(fmt.Sprintf("%02d:%02d", st.Hour, st.Minute))
+		if firstLines == nil {
+			continue
+		}
+		if first, last := firstLines[i], firstLines[i]+st.Lines-1; first == last {
+			times[i] += fmt.Sprintf(" (L%d)", first)
+		} else {
+			times[i] += fmt.Sprintf(" (L%d-%d)", first, last)
+		}
+	}
+
+	if firstLines == nil {
+		return strings.Join(times, " & ")
+	}
+	return strings.Join(times, ", ")
+}
+
+// scheduleCourts returns the match's court numbers for display, e.g.
+// "Cts 7, 8, 9", or "" if the schedule doesn't say.
+func scheduleCourts(s usta.Schedule) string {
+	switch len(s.Courts) {
+	case 0:
+		return ""
+	case 1:
+		return "Ct " + s.Courts[0]
+	default:
+		return "Cts " + strings.Join(s.Courts, ", ")
+	}
+}
+
+// rescheduledNote returns e.g. "rescheduled from Tue 4/14" for a match that
+// has moved since a previous run, or "" if it hasn't.
+func rescheduledNote(m usta.Match) string {
+	if from := rescheduledFrom(m); from != "" {
+		return "rescheduled from " + from
+	}
+	return ""
+}
+
+// rescheduledFrom returns the day a match was moved from, e.g. "Tue 4/14",
+// or its old start time if it moved to another time on the same day.
+func rescheduledFrom(m usta.Match) string {
+	from := m.RescheduledFrom
+	if from.IsZero() {
+		return ""
+	}
+	from = from.In(m.Date.Location())
+
+	fy, fm, fd := from.Date()
+	y, mo, d := m.Date.Date()
+	if fy == y && fm == mo && fd == d {
+		if t := formatMatchTime(from); t != "" {
+			return t
+		}
+	}
+	return from.Format("Mon 1/2")
+}
+
+// scheduleDescription returns the schedule details for a calendar event
+// description, one per line. The gate code, and any access codes in the
+// note, are redacted unless showGateCode is set.
+func scheduleDescription(m usta.Match, showGateCode bool) string {
+	s := m.Schedule
+
+	var lines []string
+	if from := rescheduledFrom(m); from != "" {
+		lines = append(lines, "Rescheduled from "+from)
+	}
+	if times := scheduleTimes(s); times != "" {
+		lines = append(lines, "Start times: "+times)
+	}
+	if courts := scheduleCourts(s); courts != "" {
+		lines = append(lines, "Courts: "+strings.Join(s.Courts, ", "))
+	}
+	if s.GateCode != "" {
+		code := redactedGateCode
+		if showGateCode {
+			code = s.GateCode
+		}
+		lines = append(lines, "Gate code: "+code)
+	}
+	if s.Note != "" {
+		note := s.Note
+		if !showGateCode {
+			note = redactAccessCodes(note)
+		}
+		lines = append(lines, note)
+	}
+	return strings.Join(lines, "\n")
+}
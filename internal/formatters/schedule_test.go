@@ -0,0 +1,65 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestScheduleTimes(t *testing.T) {
+	require.Empty(t, scheduleTimes(usta.Schedule{Starts: []usta.StartTime{{Hour: 19, Minute: 30, Lines: 3}}}))
+
+	staggered := usta.Schedule{Starts: []usta.StartTime{{Hour: 18, Lines: 2}, {Hour: 19, Minute: 30, Lines: 1}}}
+	require.Equal(t, "6pm (L1-2), 7:30pm (L3)", scheduleTimes(staggered))
+
+	unknownLines := usta.Schedule{Starts: []usta.StartTime{{Hour: 18, Minute: 30}, {Hour: 19, Minute: 45}}}
+	require.Equal(t, "6:30pm & 7:45pm", scheduleTimes(unknownLines))
+}
+
+func TestScheduleDescriptionRedactsGateCode(t *testing.T) {
+	s := usta.Schedule{
+		Starts:   []usta.StartTime{{Hour: 18, Minute: 30}, {Hour: 19, Minute: 45}},
+		Courts:   []string{"7", "8"},
+		GateCode: "24865",
+	}
+
+	redacted := scheduleDescription(usta.Match{Schedule: s}, false)
+	require.NotContains(t, redacted, "24865")
+	require.Contains(t, redacted, "Gate code: "+redactedGateCode)
+	require.Contains(t, redacted, "Start times: 6:30pm & 7:45pm")
+	require.Contains(t, redacted, "Courts: 7, 8")
+
+	require.Contains(t, scheduleDescription(usta.Match{Schedule: s}, true), "Gate code: 24865")
+}
+
+func TestScheduleDescriptionRedactsCodesInNote(t *testing.T) {
+	for _, note := range []string{
+		"use gate 24865 by the pool",
+		"Access code 24865, park in back",
+		"keypad #24865",
+	} {
+		s := usta.Schedule{Note: note}
+		redacted := scheduleDescription(usta.Match{Schedule: s}, false)
+		require.NotContains(t, redacted, "24865", note)
+		require.Contains(t, redacted, redactedGateCode, note)
+		require.Contains(t, scheduleDescription(usta.Match{Schedule: s}, true), "24865", note)
+	}
+
+	note := "Warm up court available at 10:30am. 1 Hilltop Rd"
+	require.Equal(t, note, scheduleDescription(usta.Match{Schedule: usta.Schedule{Note: note}}, false))
+}
+
+func TestRescheduledNote(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*60*60)
+	m := usta.Match{Date: time.Date(2026, 4, 16, 18, 30, 0, 0, loc), HasTime: true}
+	require.Empty(t, rescheduledNote(m))
+
+	m.RescheduledFrom = time.Date(2026, 4, 14, 18, 30, 0, 0, loc)
+	require.Equal(t, "rescheduled from Tue 4/14", rescheduledNote(m))
+	require.Contains(t, scheduleDescription(m, false), "Rescheduled from Tue 4/14")
+
+	m.RescheduledFrom = time.Date(2026, 4, 16, 19, 0, 0, 0, loc)
+	require.Equal(t, "rescheduled from 7pm", rescheduledNote(m))
+}
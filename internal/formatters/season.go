@@ -0,0 +1,151 @@
+package formatters
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/core"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// SeasonRecord is one club team's season so far, as saved in the data file.
+type SeasonRecord struct {
+	Team       string `json:"team"` // e.g. "👭3.5"
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	PointsWon  int    `json:"points_won"`
+	PointsLost int    `json:"points_lost"`
+	HomeWins   int    `json:"home_wins"`
+	HomeLosses int    `json:"home_losses"`
+	AwayWins   int    `json:"away_wins"`
+	AwayLosses int    `json:"away_losses"`
+	Streak     int    `json:"streak"` // matches won in a row, or lost if negative
+}
+
+// StreakText returns the streak as e.g. "W3" or "L1".
+func (r SeasonRecord) StreakText() string {
+	switch {
+	case r.Streak > 0:
+		return fmt.Sprintf("W%d", r.Streak)
+	case r.Streak < 0:
+		return fmt.Sprintf("L%d", -r.Streak)
+	default:
+		return ""
+	}
+}
+
+// BuildSeason returns the season so far of each club team that has played a
+// match before boundary, ordered by team label.
+func BuildSeason(org *usta.Organization, boundary time.Time) []SeasonRecord {
+	var records []SeasonRecord
+	for _, s := range core.SeasonSummary(org, boundary) {
+		if s.Played() == 0 {
+			continue
+		}
+		records = append(records, SeasonRecord{
+			Team:       teamLabel(org, s.Team),
+			Wins:       s.Wins,
+			Losses:     s.Losses,
+			PointsWon:  s.PointsWon,
+			PointsLost: s.PointsLost,
+			HomeWins:   s.HomeWins,
+			HomeLosses: s.HomeLosses,
+			AwayWins:   s.AwayWins,
+			AwayLosses: s.AwayLosses,
+			Streak:     s.Streak,
+		})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Team < records[j].Team
+	})
+
+	return records
+}
+
+type SeasonData struct {
+	OrgShortName string
+	Teams        []SeasonRecord
+	Warnings     []string
+}
+
+// hasSeason reports whether any club team has a season record to render.
+func (d *PreparedData) hasSeason() bool {
+	return len(d.season()) > 0
+}
+
+func (d *PreparedData) buildSeasonDisplay() SeasonData {
+	return SeasonData{
+		OrgShortName: d.orgShortName(),
+		Teams:        d.season(),
+	}
+}
+
+const seasonHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<style>
+  body {
+    font-family: 'Marker Felt', cursive;
+    margin: 0;
+    padding: 20px 24px;
+    display: inline-block;
+    white-space: nowrap;
+  }
+  .title {
+    font-size: 28px;
+    font-weight: bold;
+    text-align: center;
+    margin-bottom: 4px;
+  }
+  .subtitle {
+    font-size: 22px;
+    font-weight: bold;
+    text-align: center;
+    margin-bottom: 16px;
+  }
+  table { border-collapse: collapse; margin: 0 auto; }
+  th { font-size: 16px; font-style: italic; padding: 2px 10px; text-align: center; }
+  td { padding: 2px 10px; font-size: 18px; text-align: center; }
+  td.team { text-align: left; font-weight: bold; }
+  tr:nth-child(even) td { background-color: #f0f0f0; }
+  .won { color: #2e7d32; }
+  .lost { color: #c62828; }
+  .warning { background-color: #fff3cd; border: 1px solid #e0a800; border-radius: 4px; padding: 6px 10px; margin-bottom: 12px; font-family: sans-serif; font-size: 14px; white-space: normal; max-width: 640px; }
+</style>
+</head>
+<body>
+  {{if .Warnings}}<div class="warning">⚠️ Some USTA data failed to load; this newsletter may be incomplete.<ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul></div>{{end}}
+  <div class="title">🏆🎾 {{.OrgShortName}} plays USTA league 🎾🏆</div>
+  <div class="subtitle">Season so far</div>
+  <table>
+    <tr><th>Team</th><th>W-L</th><th>Points</th><th>Home</th><th>Away</th><th>Streak</th></tr>
+    {{range .Teams}}
+    <tr>
+      <td class="team">{{.Team}}</td>
+      <td>{{.Wins}}-{{.Losses}}</td>
+      <td>{{.PointsWon}}-{{.PointsLost}}</td>
+      <td>{{.HomeWins}}-{{.HomeLosses}}</td>
+      <td>{{.AwayWins}}-{{.AwayLosses}}</td>
+      <td class="{{if gt .Streak 0}}won{{else if lt .Streak 0}}lost{{end}}">{{.StreakText}}</td>
+    </tr>
+    {{end}}
+  </table>
+</body>
+</html>`
+
+func RenderSeasonHTML(data SeasonData) (string, error) {
+	tmpl, err := template.New("season").Parse(seasonHTML)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
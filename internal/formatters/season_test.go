@@ -0,0 +1,43 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestBuildSeason(t *testing.T) {
+	org := makeTestOrg()
+	opponent := &usta.Team{ID: 2, Organization: &usta.Organization{ID: 300, Name: "Almaden Valley Athletic Club"}}
+	ours := &usta.Team{ID: 1, Name: "Adult 18+ Womens 3.5", Organization: org}
+	ours.Matches = []usta.Match{
+		{HomeTeam: ours, VisitingTeam: opponent, Outcome: usta.Outcome{WinningTeam: ours, WinnerPoints: 3, LoserPoints: 2}},
+		{HomeTeam: opponent, VisitingTeam: ours, Outcome: usta.Outcome{WinningTeam: ours, WinnerPoints: 4, LoserPoints: 1}},
+	}
+	org.Teams = []*usta.Team{ours, {ID: 3, Name: "Adult 18+ Mens 4.0", Organization: org}}
+
+	records := BuildSeason(org, time.Time{})
+	require.Equal(t, []SeasonRecord{{
+		Team:      "👭3.5",
+		Wins:      2,
+		PointsWon: 7, PointsLost: 3,
+		HomeWins: 1,
+		AwayWins: 1,
+		Streak:   2,
+	}}, records)
+	require.Equal(t, "W2", records[0].StreakText())
+
+	output := &bytes.Buffer{}
+	data := &PreparedData{Org: org, Season: records}
+	require.NoError(t, NewConsoleFormatter().FormatSeason(data, Config{Writer: output}))
+	require.Contains(t, output.String(), "Season so far")
+	require.Contains(t, output.String(), "7-3")
+	require.Contains(t, output.String(), "W2")
+
+	html, err := seasonPageHTML(data, false)
+	require.NoError(t, err)
+	require.Contains(t, html, `<td class="won">W2</td>`)
+}
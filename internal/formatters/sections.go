@@ -0,0 +1,88 @@
+package formatters
+
+import (
+	"strings"
+)
+
+// NewCombined returns the data for one newsletter covering several
+// organizations, with a section per club in the order given.
+func NewCombined(sections []*PreparedData) *PreparedData {
+	return &PreparedData{Sections: sections}
+}
+
+// combined reports whether d is a combined newsletter of several clubs.
+func (d *PreparedData) combined() bool {
+	return len(d.Sections) > 0
+}
+
+// sections returns the clubs in d: its sections if it's combined, or else
+// just d itself.
+func (d *PreparedData) sections() []*PreparedData {
+	if d.combined() {
+		return d.Sections
+	}
+	return []*PreparedData{d}
+}
+
+// anySection reports whether has is true of any of d's sections.
+func (d *PreparedData) anySection(has func(*PreparedData) bool) bool {
+	for _, s := range d.Sections {
+		if has(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionHeading returns the heading for one club's part of a console or PDF
+// section, e.g. "Recent matches" or, in a combined newsletter, "Recent
+// matches: ASRC".
+func sectionHeading(d, s *PreparedData, title string) string {
+	if !d.combined() {
+		return title
+	}
+	return title + ": " + s.orgShortName()
+}
+
+// combineHTMLDocuments joins HTML pages rendered from the same template into
+// one page, keeping the head of the first and wrapping the body of each in a
+// section.
+func combineHTMLDocuments(docs []string) string {
+	switch len(docs) {
+	case 0:
+		return ""
+	case 1:
+		return docs[0]
+	}
+
+	first := docs[0]
+	start, end := bodyBounds(first)
+	if start < 0 {
+		return strings.Join(docs, "\n")
+	}
+
+	var b strings.Builder
+	b.WriteString(first[:start])
+	for _, doc := range docs {
+		s, e := bodyBounds(doc)
+		if s < 0 {
+			continue
+		}
+		b.WriteString("\n<section class=\"club\">")
+		b.WriteString(doc[s:e])
+		b.WriteString("</section>\n")
+	}
+	b.WriteString(first[end:])
+	return b.String()
+}
+
+// bodyBounds returns the offsets of the content between <body> and </body>,
+// or -1 if doc has no body.
+func bodyBounds(doc string) (start, end int) {
+	open := strings.Index(doc, "<body>")
+	close := strings.LastIndex(doc, "</body>")
+	if open < 0 || close < open {
+		return -1, -1
+	}
+	return open + len("<body>"), close
+}
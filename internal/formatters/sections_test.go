@@ -0,0 +1,45 @@
+package formatters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationDataFilePath(t *testing.T) {
+	require.Equal(t, "out/data.json", OrganizationDataFilePath("out/data.json", 225, 1))
+	require.Equal(t, "out/data_225.json", OrganizationDataFilePath("out/data.json", 225, 2))
+	require.Equal(t, "", OrganizationDataFilePath("", 225, 2))
+}
+
+func TestCombinedSections(t *testing.T) {
+	asrc := &PreparedData{DataFile: &DataFile{
+		OrgShortName: "ASRC",
+		Warnings:     []string{"team 1: timeout", "flight 9: timeout"},
+	}}
+	avac := &PreparedData{DataFile: &DataFile{
+		OrgShortName: "AVAC",
+		Warnings:     []string{"flight 9: timeout"},
+	}}
+	d := NewCombined([]*PreparedData{asrc, avac})
+
+	require.Equal(t, "ASRC-AVAC", d.orgShortName())
+	require.Equal(t, []string{"team 1: timeout", "flight 9: timeout"}, d.warnings())
+	require.Equal(t, "Recent matches: AVAC", sectionHeading(d, avac, "Recent matches"))
+	require.Equal(t, "Recent matches", sectionHeading(asrc, asrc, "Recent matches"))
+}
+
+func TestCombineHTMLDocuments(t *testing.T) {
+	page := func(body string) string {
+		return "<html><head><title>t</title></head><body>" + body + "</body></html>"
+	}
+
+	require.Equal(t, page("a"), combineHTMLDocuments([]string{page("a")}))
+	require.Equal(t,
+		"<html><head><title>t</title></head><body>"+
+			"\n<section class=\"club\">a</section>\n"+
+			"\n<section class=\"club\">b</section>\n"+
+			"</body></html>",
+		combineHTMLDocuments([]string{page("a"), page("b")}),
+	)
+}
@@ -0,0 +1,167 @@
+package formatters
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// FlightStandingsRecord is the standings of one flight that a club team plays
+// in, as saved in the data file.
+type FlightStandingsRecord struct {
+	Flight string           `json:"flight"`
+	Teams  string           `json:"teams"` // our team(s) in the flight, e.g. "👭3.5"
+	Rows   []StandingRecord `json:"rows"`
+}
+
+// StandingRecord is one team's row in a flight's standings.
+type StandingRecord struct {
+	Position         int     `json:"position"`
+	Team             string  `json:"team"`
+	IsOurs           bool    `json:"is_ours,omitempty"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	IndividualWinPct float64 `json:"individual_win_pct"`
+	Remaining        int     `json:"remaining"`
+}
+
+// BuildStandings returns the standings of every flight a club team plays in,
+// with the club's own teams marked.
+func BuildStandings(org *usta.Organization) []FlightStandingsRecord {
+	ourTeams := map[int]*usta.Team{}
+	for _, t := range org.Teams {
+		ourTeams[t.ID] = t
+	}
+
+	var records []FlightStandingsRecord
+	seen := map[int]bool{}
+	for _, t := range org.Teams {
+		f := t.Flight
+		if f == nil || len(f.Standings) == 0 || seen[f.ID] {
+			continue
+		}
+		seen[f.ID] = true
+
+		rec := FlightStandingsRecord{Flight: f.Name}
+		for _, s := range f.Standings {
+			row := StandingRecord{
+				Position:         s.Position,
+				Team:             s.TeamName,
+				Wins:             s.Wins,
+				Losses:           s.Losses,
+				IndividualWinPct: s.IndividualWinPct,
+				Remaining:        s.Remaining,
+			}
+			if ot, ok := ourTeams[s.TeamID]; ok {
+				label := teamLabel(org, ot)
+				row.IsOurs = true
+				row.Team = org.ShortName() + " " + label
+				if rec.Teams != "" {
+					rec.Teams += ", "
+				}
+				rec.Teams += label
+			}
+			rec.Rows = append(rec.Rows, row)
+		}
+		records = append(records, rec)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Teams < records[j].Teams
+	})
+
+	return records
+}
+
+// teamLabel returns the short emoji label for one of our teams, e.g. "👭3.5A".
+func teamLabel(org *usta.Organization, t *usta.Team) string {
+	d := t.Display()
+	return d.GenderEmoji() + d.Label() + suffixForTeam(org, t) + d.DaytimeEmoji()
+}
+
+type StandingsData struct {
+	OrgShortName string
+	Flights      []FlightStandingsRecord
+	Warnings     []string
+}
+
+// hasStandings reports whether there are any flight standings to render.
+func (d *PreparedData) hasStandings() bool {
+	return len(d.standings()) > 0
+}
+
+func (d *PreparedData) buildStandingsDisplay() StandingsData {
+	return StandingsData{
+		OrgShortName: d.orgShortName(),
+		Flights:      d.standings(),
+	}
+}
+
+const standingsHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<style>
+  body {
+    font-family: 'Marker Felt', cursive;
+    margin: 0;
+    padding: 20px 24px;
+    display: inline-block;
+    white-space: nowrap;
+  }
+  .title {
+    font-size: 28px;
+    font-weight: bold;
+    text-align: center;
+    margin-bottom: 4px;
+  }
+  .subtitle {
+    font-size: 22px;
+    font-weight: bold;
+    text-align: center;
+    margin-bottom: 16px;
+  }
+  .flight { font-size: 20px; font-weight: bold; font-style: italic; margin: 14px 0 4px; }
+  table { border-collapse: collapse; }
+  th { font-size: 16px; font-style: italic; padding: 2px 10px; text-align: center; }
+  td { padding: 2px 10px; font-size: 18px; text-align: center; }
+  td.team { text-align: left; }
+  .ours { font-weight: bold; background-color: #fff59d; }
+  .warning { background-color: #fff3cd; border: 1px solid #e0a800; border-radius: 4px; padding: 6px 10px; margin-bottom: 12px; font-family: sans-serif; font-size: 14px; white-space: normal; max-width: 640px; }
+</style>
+</head>
+<body>
+  {{if .Warnings}}<div class="warning">⚠️ Some USTA data failed to load; this newsletter may be incomplete.<ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul></div>{{end}}
+  <div class="title">🏆🎾 {{.OrgShortName}} plays USTA league 🎾🏆</div>
+  <div class="subtitle">Standings</div>
+  {{range .Flights}}
+  <div class="flight">{{.Teams}} · {{.Flight}}</div>
+  <table>
+    <tr><th>#</th><th>Team</th><th>W-L</th><th>Ind. %</th><th>Left</th></tr>
+    {{range .Rows}}
+    <tr class="{{if .IsOurs}}ours{{end}}">
+      <td>{{.Position}}</td>
+      <td class="team">{{.Team}}</td>
+      <td>{{.Wins}}-{{.Losses}}</td>
+      <td>{{printf "%.0f%%" .IndividualWinPct}}</td>
+      <td>{{.Remaining}}</td>
+    </tr>
+    {{end}}
+  </table>
+  {{end}}
+</body>
+</html>`
+
+func RenderStandingsHTML(data StandingsData) (string, error) {
+	tmpl, err := template.New("standings").Parse(standingsHTML)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,38 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+func TestBuildStandings(t *testing.T) {
+	org := makeTestOrg()
+	flight := &usta.Flight{
+		ID:   9,
+		Name: "Flight 2",
+		Standings: []usta.Standing{
+			{Position: 1, TeamID: 2, TeamName: "COURTSIDE 18AW3.5", Wins: 5, Losses: 1, IndividualWinPct: 70, Remaining: 2},
+			{Position: 2, TeamID: 1, TeamName: "ALMADEN SR 18AW3.5", Wins: 4, Losses: 2, IndividualWinPct: 62.5, Remaining: 2},
+		},
+	}
+	org.Teams = []*usta.Team{
+		{ID: 1, Name: "Adult 18+ Womens 3.5", Organization: org, Flight: flight},
+	}
+
+	records := BuildStandings(org)
+	require.Len(t, records, 1)
+	require.Equal(t, "Flight 2", records[0].Flight)
+	require.Equal(t, "👭3.5", records[0].Teams)
+	require.False(t, records[0].Rows[0].IsOurs)
+	require.True(t, records[0].Rows[1].IsOurs)
+	require.Equal(t, "ASRC 👭3.5", records[0].Rows[1].Team)
+
+	output := &bytes.Buffer{}
+	data := &PreparedData{Org: org, Standings: records}
+	require.NoError(t, NewConsoleFormatter().FormatStandings(data, Config{Writer: output}))
+	require.Contains(t, output.String(), "» ASRC 👭3.5")
+	require.Contains(t, output.String(), "70%")
+}
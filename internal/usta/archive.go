@@ -0,0 +1,86 @@
+package usta
+
+import (
+	"fmt"
+	"time"
+)
+
+// PageArchive is a directory of raw pages saved by a run, so that the run can
+// be replayed later without network access, even after the pages on the USTA
+// site have changed.
+type PageArchive struct {
+	pages pageStore
+}
+
+var (
+	// recordArchive, if set, receives every page fetchPage returns.
+	recordArchive *PageArchive
+	// replayArchive, if set, serves every page fetchPage is asked for,
+	// instead of the network and the disk cache.
+	replayArchive *PageArchive
+)
+
+// NewPageArchive returns a PageArchive storing pages under dir.
+func NewPageArchive(dir string) *PageArchive {
+	return &PageArchive{pages: pageStore{dir: dir}}
+}
+
+// Dir returns the directory the archive is stored in.
+func (a *PageArchive) Dir() string {
+	return a.pages.dir
+}
+
+// SetRecordArchive sets the archive every fetched page is saved to. Pass nil
+// to stop recording.
+func SetRecordArchive(a *PageArchive) {
+	recordArchive = a
+}
+
+// SetReplayArchive sets the archive pages are served from instead of the
+// USTA site. Pages missing from the archive fail to load. Pass nil to fetch
+// from the network again.
+func SetReplayArchive(a *PageArchive) {
+	replayArchive = a
+}
+
+// FetchedAt returns the time the latest page in the archive was fetched: the
+// time of the run that recorded it.
+func (a *PageArchive) FetchedAt() (time.Time, error) {
+	metas, _, err := a.pages.list()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("listing archive: %w", err)
+	}
+	var latest time.Time
+	for _, meta := range metas {
+		if meta.FetchedAt.After(latest) {
+			latest = meta.FetchedAt
+		}
+	}
+	if latest.IsZero() {
+		return time.Time{}, fmt.Errorf("no pages in archive %s", a.pages.dir)
+	}
+	return latest, nil
+}
+
+// save stores a page body with its URL and the time it was fetched.
+func (a *PageArchive) save(u string, kind PageKind, body []byte, fetchedAt time.Time) error {
+	if err := a.pages.put(&storedPage{
+		meta: pageMeta{URL: u, Kind: kind, FetchedAt: fetchedAt},
+		body: body,
+	}); err != nil {
+		return fmt.Errorf("archiving page: %w", err)
+	}
+	return nil
+}
+
+// load returns the archived body of the page at u.
+func (a *PageArchive) load(u string) ([]byte, error) {
+	p, err := a.pages.get(u)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("page not in archive %s: %s", a.pages.dir, u)
+	}
+	return p.body, nil
+}
@@ -0,0 +1,66 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>recorded</html>"))
+	}))
+
+	archive := NewPageArchive(t.TempDir())
+	SetRecordArchive(archive)
+	body, err := fetchPage(context.Background(), PageTeam, srv.URL)
+	SetRecordArchive(nil)
+	require.NoError(t, err)
+	require.Equal(t, "<html>recorded</html>", string(body))
+
+	// Replaying must not touch the network.
+	srv.Close()
+	SetReplayArchive(archive)
+	defer SetReplayArchive(nil)
+
+	body, err = fetchPage(context.Background(), PageTeam, srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, "<html>recorded</html>", string(body))
+
+	_, err = fetchPage(context.Background(), PageTeam, srv.URL+"/missing")
+	require.ErrorContains(t, err, "page not in archive")
+}
+
+func TestReplayLoadTeam(t *testing.T) {
+	const id = 424242
+	archive := NewPageArchive(t.TempDir())
+	require.NoError(t, archive.save(fmt.Sprintf(teamURL, id), PageTeam, []byte(testTeamMetadataHTML), time.Now()))
+
+	SetReplayArchive(archive)
+	defer SetReplayArchive(nil)
+
+	team, err := LoadTeam(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, "2026 Adult 55 & Over Womens 3.0 Daytime", team.Name)
+	require.Equal(t, 4321, team.FlightID)
+}
+
+func TestPageArchiveFetchedAt(t *testing.T) {
+	archive := NewPageArchive(t.TempDir())
+	_, err := archive.FetchedAt()
+	require.ErrorContains(t, err, "no pages in archive")
+
+	first := time.Date(2026, 4, 20, 7, 0, 0, 0, time.UTC)
+	second := first.Add(2 * time.Minute)
+	require.NoError(t, archive.save("https://example.com/b", PageTeam, []byte("b"), second))
+	require.NoError(t, archive.save("https://example.com/a", PageTeam, []byte("a"), first))
+
+	fetchedAt, err := archive.FetchedAt()
+	require.NoError(t, err)
+	require.True(t, second.Equal(fetchedAt))
+}
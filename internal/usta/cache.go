@@ -58,12 +58,20 @@ func (c *cache) set(key string, value interface{}) {
 
 // Global caches with 10-minute TTL
 var (
-	teamCache = newCache(10 * time.Minute)
-	orgCache  = newCache(10 * time.Minute)
+	teamCache      = newCache(10 * time.Minute)
+	orgCache       = newCache(10 * time.Minute)
+	scorecardCache = newCache(10 * time.Minute)
+	rosterCache    = newCache(10 * time.Minute)
+	flightCache    = newCache(10 * time.Minute)
+	orgListCache   = newCache(10 * time.Minute)
 )
 
 // Global singleflight groups for request deduplication
 var (
-	teamGroup singleflight.Group
-	orgGroup  singleflight.Group
+	teamGroup      singleflight.Group
+	orgGroup       singleflight.Group
+	scorecardGroup singleflight.Group
+	rosterGroup    singleflight.Group
+	flightGroup    singleflight.Group
+	orgListGroup   singleflight.Group
 )
@@ -0,0 +1,162 @@
+package usta
+
+import (
+	"fmt"
+	"time"
+)
+
+// PageKind identifies the kind of USTA page being fetched. Each kind can be
+// cached on disk for a different length of time.
+type PageKind string
+
+const (
+	PageOrganization     PageKind = "organization"
+	PageTeam             PageKind = "team"
+	PageScorecard        PageKind = "scorecard"
+	PageFlight           PageKind = "flight"
+	PageOrganizationList PageKind = "orglist"
+)
+
+// DefaultPageTTLs are the on-disk cache TTLs used for page kinds that aren't
+// configured explicitly. Schedules and outcomes on team pages change often;
+// organization pages and posted scorecards rarely do.
+var DefaultPageTTLs = map[PageKind]time.Duration{
+	PageOrganization: 24 * time.Hour,
+	PageTeam:         time.Hour,
+	PageScorecard:    24 * time.Hour,
+	PageFlight:       time.Hour,
+	// The list of organizations barely changes within a season.
+	PageOrganizationList: 7 * 24 * time.Hour,
+}
+
+// DiskCache is a persistent cache of raw page bodies keyed by URL. Stale
+// entries are kept so they can be revalidated with a conditional request.
+type DiskCache struct {
+	pages pageStore
+	ttls  map[PageKind]time.Duration
+}
+
+// CacheStats summarizes the contents of a DiskCache.
+type CacheStats struct {
+	Dir     string
+	Entries map[PageKind]int
+	Stale   map[PageKind]int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// diskCache is the cache used by fetchDocument; nil disables on-disk caching.
+var diskCache *DiskCache
+
+// SetDiskCache sets the on-disk cache used when fetching pages. Pass nil to
+// disable on-disk caching.
+func SetDiskCache(c *DiskCache) {
+	diskCache = c
+}
+
+// NewDiskCache returns a DiskCache storing pages under dir. ttls overrides
+// DefaultPageTTLs for the given page kinds.
+func NewDiskCache(dir string, ttls map[PageKind]time.Duration) *DiskCache {
+	c := &DiskCache{
+		pages: pageStore{dir: dir},
+		ttls:  make(map[PageKind]time.Duration, len(DefaultPageTTLs)),
+	}
+	for k, v := range DefaultPageTTLs {
+		c.ttls[k] = v
+	}
+	for k, v := range ttls {
+		c.ttls[k] = v
+	}
+	return c
+}
+
+// Dir returns the directory the cache is stored in.
+func (c *DiskCache) Dir() string {
+	return c.pages.dir
+}
+
+// TTL returns how long pages of the given kind are considered fresh.
+func (c *DiskCache) TTL(kind PageKind) time.Duration {
+	return c.ttls[kind]
+}
+
+// get returns the cached page for u, whether fresh or stale, or nil if the
+// page isn't cached.
+func (c *DiskCache) get(u string) *storedPage {
+	p, err := c.pages.get(u)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// fresh reports whether p was fetched within its kind's TTL.
+func (c *DiskCache) fresh(p *storedPage) bool {
+	return time.Since(p.meta.FetchedAt) < c.ttls[p.meta.Kind]
+}
+
+// put stores a page body and its validators.
+func (c *DiskCache) put(u string, kind PageKind, body []byte, etag, lastModified string) error {
+	if err := c.pages.put(&storedPage{
+		meta: pageMeta{
+			URL:          u,
+			Kind:         kind,
+			ETag:         etag,
+			LastModified: lastModified,
+			FetchedAt:    time.Now(),
+		},
+		body: body,
+	}); err != nil {
+		return fmt.Errorf("caching page: %w", err)
+	}
+	return nil
+}
+
+// touch marks a cached page as freshly revalidated.
+func (c *DiskCache) touch(p *storedPage) error {
+	p.meta.FetchedAt = time.Now()
+	if err := c.pages.putMeta(p.meta); err != nil {
+		return fmt.Errorf("caching page: %w", err)
+	}
+	return nil
+}
+
+// Stats returns a summary of the cached pages.
+func (c *DiskCache) Stats() (CacheStats, error) {
+	stats := CacheStats{
+		Dir:     c.pages.dir,
+		Entries: map[PageKind]int{},
+		Stale:   map[PageKind]int{},
+	}
+
+	metas, size, err := c.pages.list()
+	if err != nil {
+		return stats, fmt.Errorf("reading cache: %w", err)
+	}
+	stats.Bytes = size
+
+	for _, meta := range metas {
+		stats.Entries[meta.Kind]++
+		if time.Since(meta.FetchedAt) >= c.ttls[meta.Kind] {
+			stats.Stale[meta.Kind]++
+		}
+		if stats.Oldest.IsZero() || meta.FetchedAt.Before(stats.Oldest) {
+			stats.Oldest = meta.FetchedAt
+		}
+		if meta.FetchedAt.After(stats.Newest) {
+			stats.Newest = meta.FetchedAt
+		}
+	}
+
+	return stats, nil
+}
+
+// Clear removes every cached page. Only the cache's own files are removed,
+// so pointing the cache at a directory that holds anything else is safe.
+func (c *DiskCache) Clear() error {
+	if err := c.pages.clear(); err != nil {
+		return fmt.Errorf("clearing cache: %w", err)
+	}
+	return nil
+}
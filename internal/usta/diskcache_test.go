@@ -0,0 +1,70 @@
+package usta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPageDiskCache(t *testing.T) {
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("<html>team</html>"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dc := NewDiskCache(dir, map[PageKind]time.Duration{PageTeam: time.Hour})
+	SetDiskCache(dc)
+	defer SetDiskCache(nil)
+
+	ctx := context.Background()
+	u := srv.URL + "/teaminfo.asp?id=1"
+
+	// First fetch goes to the network and populates the cache.
+	body, err := fetchPage(ctx, PageTeam, u)
+	require.NoError(t, err)
+	require.Equal(t, "<html>team</html>", string(body))
+	require.Equal(t, 1, requests)
+
+	// Second fetch is served from the fresh cache.
+	body, err = fetchPage(ctx, PageTeam, u)
+	require.NoError(t, err)
+	require.Equal(t, "<html>team</html>", string(body))
+	require.Equal(t, 1, requests)
+
+	// Once stale, the page is revalidated with its ETag.
+	dc.ttls[PageTeam] = 0
+	body, err = fetchPage(ctx, PageTeam, u)
+	require.NoError(t, err)
+	require.Equal(t, "<html>team</html>", string(body))
+	require.Equal(t, 2, requests)
+	require.Equal(t, 1, notModified)
+
+	stats, err := dc.Stats()
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Entries[PageTeam])
+	require.Equal(t, 1, stats.Stale[PageTeam])
+
+	// Clearing leaves anything else in the directory alone.
+	other := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(other, []byte("keep"), 0644))
+	require.NoError(t, dc.Clear())
+	stats, err = dc.Stats()
+	require.NoError(t, err)
+	require.Empty(t, stats.Entries)
+	require.FileExists(t, other)
+}
@@ -0,0 +1,167 @@
+package usta
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FetcherConfig controls how politely pages are fetched from the USTA site.
+type FetcherConfig struct {
+	// Concurrency is the maximum number of requests in flight at once.
+	Concurrency int
+	// RequestsPerSecond is the maximum rate at which requests are started;
+	// zero or less means unlimited.
+	RequestsPerSecond float64
+	// MaxRetries is how many times a request failing with a 5xx status or a
+	// network error is retried.
+	MaxRetries int
+	// RetryBackoff is the base delay before the first retry; it doubles on
+	// each subsequent retry and is jittered by up to 50%.
+	RetryBackoff time.Duration
+}
+
+// DefaultFetcherConfig is the fetcher configuration used unless
+// ConfigureFetcher is called.
+var DefaultFetcherConfig = FetcherConfig{
+	Concurrency:       8,
+	RequestsPerSecond: 5,
+	MaxRetries:        3,
+	RetryBackoff:      500 * time.Millisecond,
+}
+
+// fetcher is shared by every loader so that limits apply across all
+// concurrent organization, team and scorecard loads.
+type fetcher struct {
+	client *http.Client
+	cfg    FetcherConfig
+	sem    chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+var defaultFetcher = newFetcher(httpClient, DefaultFetcherConfig)
+
+// ConfigureFetcher replaces the shared fetcher's limits and retry policy.
+func ConfigureFetcher(cfg FetcherConfig) {
+	defaultFetcher = newFetcher(httpClient, cfg)
+}
+
+func newFetcher(client *http.Client, cfg FetcherConfig) *fetcher {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	return &fetcher{
+		client: client,
+		cfg:    cfg,
+		sem:    make(chan struct{}, cfg.Concurrency),
+	}
+}
+
+// do sends req, waiting for a concurrency slot and the rate limit, and
+// retries 5xx responses and network errors with jittered exponential backoff.
+// The concurrency slot is held until the response body is closed.
+func (f *fetcher) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	select {
+	case f.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release := func() { <-f.sem }
+
+	for attempt := 0; ; attempt++ {
+		if err := f.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+
+		res, err := f.client.Do(req.Clone(ctx))
+		if err == nil && res.StatusCode < 500 {
+			res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+			return res, nil
+		}
+		if ctx.Err() != nil || attempt >= f.cfg.MaxRetries {
+			if err == nil {
+				res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+				return res, nil
+			}
+			release()
+			return nil, err
+		}
+
+		if err == nil {
+			slog.Debug("retrying request", "url", req.URL.String(), "status", res.StatusCode, "attempt", attempt+1)
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		} else {
+			slog.Debug("retrying request", "url", req.URL.String(), "error", err, "attempt", attempt+1)
+		}
+
+		if err := sleep(ctx, f.backoff(attempt)); err != nil {
+			release()
+			return nil, err
+		}
+	}
+}
+
+// wait blocks until the rate limit allows another request to start.
+func (f *fetcher) wait(ctx context.Context) error {
+	if f.cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / f.cfg.RequestsPerSecond)
+
+	f.mu.Lock()
+	now := time.Now()
+	if f.next.Before(now) {
+		f.next = now
+	}
+	delay := f.next.Sub(now)
+	f.next = f.next.Add(interval)
+	f.mu.Unlock()
+
+	return sleep(ctx, delay)
+}
+
+func (f *fetcher) backoff(attempt int) time.Duration {
+	d := f.cfg.RetryBackoff << attempt
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody releases the fetcher's concurrency slot once the response
+// body has been closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
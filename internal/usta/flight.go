@@ -0,0 +1,225 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	flightURL = "https://leagues.ustanorcal.com/flightresults.asp?id=%d"
+)
+
+// Flight represents a USTA NorCal flight: the group of teams that play each
+// other during the regular season.
+type Flight struct {
+	ID        int        `json:"id" yaml:"id"`
+	Name      string     `json:"name" yaml:"name"`
+	Standings []Standing `json:"standings" yaml:"standings"`
+}
+
+// Standing is one team's row in its flight's standings.
+type Standing struct {
+	Position int    `json:"position" yaml:"position"`
+	TeamID   int    `json:"team_id" yaml:"team_id"`
+	TeamName string `json:"team_name" yaml:"team_name"`
+
+	Wins   int `json:"wins" yaml:"wins"`
+	Losses int `json:"losses" yaml:"losses"`
+	// IndividualWinPct is the percentage (0-100) of individual matches won.
+	IndividualWinPct float64 `json:"individual_win_pct" yaml:"individual_win_pct"`
+	// Remaining is the number of regular-season matches still to play.
+	Remaining int `json:"remaining" yaml:"remaining"`
+}
+
+// LoadFlight loads the standings for the flight with the given ID.
+func LoadFlight(ctx context.Context, id int) (*Flight, error) {
+	cacheKey := fmt.Sprintf("flight:%d", id)
+
+	// Use singleflight to deduplicate concurrent requests
+	result, err, _ := flightGroup.Do(cacheKey, func() (interface{}, error) {
+		if cached, ok := flightCache.get(cacheKey); ok {
+			slog.Debug("flight cache hit", "flight_id", id)
+			return cached.(*Flight), nil
+		}
+
+		u := fmt.Sprintf(flightURL, id)
+		slog.Debug("fetching flight page", "flight_id", id, "url", u)
+		doc, err := fetchDocument(ctx, PageFlight, u)
+		if err != nil {
+			return nil, err
+		}
+
+		f := &Flight{
+			ID:        id,
+			Name:      strings.TrimSpace(doc.Find("table tbody tr td b").First().Text()),
+			Standings: parseStandings(doc),
+		}
+		flightCache.set(cacheKey, f)
+
+		return f, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*Flight), nil
+}
+
+// LoadFlight loads the standings of the flight the team plays in. It returns
+// nil if the team's flight is unknown.
+func (t *Team) LoadFlight(ctx context.Context) (*Flight, error) {
+	if t.Flight != nil {
+		return t.Flight, nil
+	}
+
+	if t.FlightID == 0 {
+		return nil, nil
+	}
+
+	f, err := LoadFlight(ctx, t.FlightID)
+	if err != nil {
+		return nil, err
+	}
+	t.Flight = f
+
+	return f, nil
+}
+
+// standingsColumns holds the column index of each standings field, or -1 if
+// the standings table has no such column.
+type standingsColumns struct {
+	position, team, wins, losses, pct, indWins, indLosses, remaining int
+}
+
+// parseStandings extracts the standings table from a flight page. The table
+// is found by its header row, which must have "Team", win and loss columns.
+func parseStandings(doc *goquery.Document) []Standing {
+	var standings []Standing
+	var cols *standingsColumns
+	var header *goquery.Selection
+
+	doc.Find("tr").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		if cols = standingsHeader(row.ChildrenFiltered("td,th")); cols != nil {
+			header = row
+			return false
+		}
+		return true
+	})
+	if cols == nil {
+		return nil
+	}
+
+	// Only the rows of the standings table itself, after its header, are
+	// standings; later tables on the page are something else.
+	table := header.Closest("table")
+	started := false
+	table.Find("tr").Each(func(i int, row *goquery.Selection) {
+		if !started {
+			started = row.IsSelection(header)
+			return
+		}
+		if !row.Closest("table").IsSelection(table) {
+			return
+		}
+		cells := row.ChildrenFiltered("td,th")
+
+		if cells.Length() <= cols.team {
+			return
+		}
+		link := cells.Eq(cols.team).Find("a[href*='teaminfo.asp']").First()
+		if link.Length() == 0 {
+			return
+		}
+
+		s := Standing{
+			Position: len(standings) + 1,
+			TeamName: cellText(link),
+		}
+		href, _ := link.Attr("href")
+		s.TeamID, _ = parseIDParam(href)
+
+		intAt := func(col int) int {
+			if col < 0 || col >= cells.Length() {
+				return 0
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(cellText(cells.Eq(col))))
+			return n
+		}
+
+		if p := intAt(cols.position); p > 0 {
+			s.Position = p
+		}
+		s.Wins = intAt(cols.wins)
+		s.Losses = intAt(cols.losses)
+		s.Remaining = intAt(cols.remaining)
+
+		if cols.pct >= 0 && cols.pct < cells.Length() {
+			pct := cellText(cells.Eq(cols.pct))
+			s.IndividualWinPct, _ = strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(pct, "%")), 64)
+			// Some flights show a fraction (".625") rather than a percentage.
+			if !strings.HasSuffix(pct, "%") && s.IndividualWinPct <= 1 {
+				s.IndividualWinPct *= 100
+			}
+		} else if w, l := intAt(cols.indWins), intAt(cols.indLosses); w+l > 0 {
+			s.IndividualWinPct = 100 * float64(w) / float64(w+l)
+		}
+
+		standings = append(standings, s)
+	})
+
+	return standings
+}
+
+// standingsHeader returns the standings column positions if cells is the
+// standings table's header row, or nil otherwise. Each column is taken from
+// the first header naming it; a second "W" and "L", after the team's
+// matches won and lost, are its individual lines won and lost.
+func standingsHeader(cells *goquery.Selection) *standingsColumns {
+	cols := &standingsColumns{-1, -1, -1, -1, -1, -1, -1, -1}
+	first := func(col *int, i int) {
+		if *col < 0 {
+			*col = i
+		}
+	}
+
+	cells.Each(func(i int, cell *goquery.Selection) {
+		h := strings.ToLower(cellText(cell))
+		switch {
+		case h == "#" || h == "pos" || h == "position" || h == "rank":
+			first(&cols.position, i)
+		case h == "team" || h == "team name":
+			first(&cols.team, i)
+		case h == "w" || h == "wins" || h == "matches won":
+			if cols.wins < 0 {
+				cols.wins = i
+			} else {
+				first(&cols.indWins, i)
+			}
+		case h == "l" || h == "losses" || h == "matches lost":
+			if cols.losses < 0 {
+				cols.losses = i
+			} else {
+				first(&cols.indLosses, i)
+			}
+		case strings.Contains(h, "%") || strings.Contains(h, "pct"):
+			first(&cols.pct, i)
+		case strings.HasPrefix(h, "ind") && strings.Contains(h, "w"):
+			first(&cols.indWins, i)
+		case strings.HasPrefix(h, "ind") && strings.Contains(h, "l"):
+			first(&cols.indLosses, i)
+		case strings.Contains(h, "remaining") || h == "left" || h == "to play":
+			first(&cols.remaining, i)
+		}
+	})
+
+	if cols.team < 0 || cols.wins < 0 || cols.losses < 0 {
+		return nil
+	}
+	return cols
+}
@@ -0,0 +1,51 @@
+package usta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
+)
+
+const testFlightHTML = `<html><body>
+<table><tbody><tr><td><b>2026 Adult 18 &amp; Over Womens 3.5 - Flight 2</b></td></tr></tbody></table>
+<table>
+  <tr><th>Team</th><th>W</th><th>L</th><th>Ind. Win %</th><th>Remaining</th></tr>
+  <tr><td><a href="teaminfo.asp?id=111">ALMADEN SR 18AW3.5A</a></td><td>5</td><td>1</td><td>70.0%</td><td>2</td></tr>
+  <tr><td><a href="teaminfo.asp?id=222">COURTSIDE 18AW3.5</a></td><td>4</td><td>2</td><td>.625</td><td>2</td></tr>
+</table>
+<table>
+  <tr><th>Team</th><th>W</th><th>L</th><th>Ind. Win %</th><th>Remaining</th></tr>
+  <tr><td><a href="teaminfo.asp?id=333">LOS GATOS 18AW3.5</a></td><td>1</td><td>5</td><td>30.0%</td><td>0</td></tr>
+</table>
+</body></html>`
+
+func TestParseStandings(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testFlightHTML))
+	require.NoError(t, err)
+
+	standings := parseStandings(doc)
+	require.Equal(t, []Standing{
+		{Position: 1, TeamID: 111, TeamName: "ALMADEN SR 18AW3.5A", Wins: 5, Losses: 1, IndividualWinPct: 70, Remaining: 2},
+		{Position: 2, TeamID: 222, TeamName: "COURTSIDE 18AW3.5", Wins: 4, Losses: 2, IndividualWinPct: 62.5, Remaining: 2},
+	}, standings)
+}
+
+func TestParseStandingsTeamAndIndividualColumns(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body>
+<table>
+  <tr><th>Pos</th><th>Team</th><th>W</th><th>L</th><th>W</th><th>L</th></tr>
+  <tr><td>1</td><td><a href="teaminfo.asp?id=111">ALMADEN SR 18AW3.5A</a></td><td>5</td><td>1</td><td>21</td><td>9</td></tr>
+  <tr><td>2</td><td><a href="teaminfo.asp?id=222">COURTSIDE 18AW3.5</a></td><td>4</td><td>2</td><td>18</td><td>12</td></tr>
+</table>
+</body></html>`))
+	require.NoError(t, err)
+
+	// The first W and L are matches; the second are individual lines.
+	standings := parseStandings(doc)
+	require.Equal(t, []Standing{
+		{Position: 1, TeamID: 111, TeamName: "ALMADEN SR 18AW3.5A", Wins: 5, Losses: 1, IndividualWinPct: 70},
+		{Position: 2, TeamID: 222, TeamName: "COURTSIDE 18AW3.5", Wins: 4, Losses: 2, IndividualWinPct: 60},
+	}, standings)
+}
@@ -1,8 +1,16 @@
 package usta
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
 )
 
 var httpClient = &http.Client{
@@ -13,3 +21,107 @@ var httpClient = &http.Client{
 		IdleConnTimeout:     90 * time.Second,
 	},
 }
+
+// fetchDocument fetches the page at u and parses it as HTML.
+func fetchDocument(ctx context.Context, kind PageKind, u string) (*goquery.Document, error) {
+	body, err := fetchPage(ctx, kind, u)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read %s page", kind)
+	}
+
+	return doc, nil
+}
+
+// fetchPage returns the body of the page at u. When replaying, the page is
+// served from the replay archive; when recording, it is also saved to the
+// record archive.
+func fetchPage(ctx context.Context, kind PageKind, u string) ([]byte, error) {
+	if replayArchive != nil {
+		slog.Debug("replaying archived page", "kind", kind, "url", u)
+		body, err := replayArchive.load(u)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not replay %s page", kind)
+		}
+		countCacheHit(ctx)
+		return body, nil
+	}
+
+	body, fetchedAt, err := fetchPageLive(ctx, kind, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if recordArchive != nil {
+		if err := recordArchive.save(u, kind, body, fetchedAt); err != nil {
+			slog.Warn("could not archive page", "url", u, "error", err)
+		}
+	}
+
+	return body, nil
+}
+
+// fetchPageLive returns the body of the page at u and when it was fetched,
+// serving it from the on-disk cache when fresh and revalidating it with a
+// conditional request when stale.
+func fetchPageLive(ctx context.Context, kind PageKind, u string) ([]byte, time.Time, error) {
+	var cached *storedPage
+	if diskCache != nil {
+		cached = diskCache.get(u)
+		if cached != nil && diskCache.fresh(cached) {
+			slog.Debug("disk cache hit", "kind", kind, "url", u)
+			countCacheHit(ctx)
+			return cached.body, cached.meta.FetchedAt, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "could not create %s request", kind)
+	}
+	if cached != nil {
+		if cached.meta.ETag != "" {
+			req.Header.Set("If-None-Match", cached.meta.ETag)
+		}
+		if cached.meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.meta.LastModified)
+		}
+	}
+
+	res, err := defaultFetcher.do(req)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "could not fetch %s page", kind)
+	}
+	defer res.Body.Close()
+	countFetched(ctx)
+
+	if res.StatusCode == http.StatusNotModified && cached != nil {
+		slog.Debug("disk cache revalidated", "kind", kind, "url", u)
+		if err := diskCache.touch(cached); err != nil {
+			slog.Warn("could not update disk cache", "url", u, "error", err)
+		}
+		return cached.body, time.Now(), nil
+	}
+	if res.StatusCode != 200 {
+		return nil, time.Time{}, fmt.Errorf("error fetching %s page, code: %d", kind, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "could not read %s page", kind)
+	}
+
+	if diskCache != nil {
+		etag := res.Header.Get("ETag")
+		lastModified := res.Header.Get("Last-Modified")
+		if err := diskCache.put(u, kind, body, etag, lastModified); err != nil {
+			slog.Warn("could not write disk cache", "url", u, "error", err)
+		}
+	}
+
+	return body, time.Now(), nil
+}
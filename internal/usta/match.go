@@ -12,41 +12,193 @@ import (
 
 // Match represents a match consisting of multiple lines.
 type Match struct {
-	Number       int
-	Date         time.Time
-	HasTime      bool
+	Number  int
+	Type    MatchType
+	Date    time.Time
+	HasTime bool
+	// Schedule holds the start times, courts and notes from the match's
+	// time cell.
+	Schedule     Schedule
 	HomeTeam     *Team
 	VisitingTeam *Team
 	Location     *Organization
 
-	Outcome struct {
-		WinningTeam  *Team
-		WinnerPoints int
-		LoserPoints  int
+	Outcome Outcome
+
+	// Derby is set by Organization.Matches when both teams belong to the
+	// organization.
+	Derby bool
+
+	// RescheduledFrom is set by ObserveSchedule to the date the match was at
+	// before it last moved, or zero if it hasn't moved.
+	RescheduledFrom time.Time
+
+	// ScorecardID is the ID of the match's scorecard page, or 0 if the
+	// match has no scorecard yet.
+	ScorecardID int
+	Lines       []Line
+}
+
+// Key identifies the match independently of which team's schedule it was
+// read from: the flight, the match number and the pair of teams.
+func (m Match) Key() string {
+	a, b := m.HomeTeam.ID, m.VisitingTeam.ID
+	if a > b {
+		a, b = b, a
+	}
+	flightID := m.HomeTeam.FlightID
+	if flightID == 0 {
+		flightID = m.VisitingTeam.FlightID
+	}
+	return fmt.Sprintf("%d/%d/%d-%d", flightID, m.Number, a, b)
+}
+
+// OutcomeKind describes how a match was decided, or why it wasn't.
+type OutcomeKind int
+
+const (
+	OutcomeUnknown OutcomeKind = iota
+	OutcomePlayed
+	OutcomeDefault
+	OutcomeForfeit
+	OutcomeRetired
+	OutcomeRainout
+	OutcomePostponed
+)
+
+func (k OutcomeKind) String() string {
+	switch k {
+	case OutcomePlayed:
+		return "played"
+	case OutcomeDefault:
+		return "default"
+	case OutcomeForfeit:
+		return "forfeit"
+	case OutcomeRetired:
+		return "retired"
+	case OutcomeRainout:
+		return "rainout"
+	case OutcomePostponed:
+		return "postponed"
+	default:
+		return "unknown"
 	}
+}
+
+// Outcome is the result of a match. WinningTeam is nil for matches that
+// haven't been decided, including rainouts and postponements.
+type Outcome struct {
+	Kind         OutcomeKind
+	WinningTeam  *Team
+	WinnerPoints int
+	LoserPoints  int
+}
+
+// Decided reports whether the match has a known result or a known reason for
+// not having one.
+func (o Outcome) Decided() bool {
+	return o.WinningTeam != nil || o.Kind == OutcomeRainout || o.Kind == OutcomePostponed
+}
+
+// LineKind is the format of a line within a match.
+type LineKind int
 
-	Lines []Line
+const (
+	LineUnknown LineKind = iota
+	LineSingles
+	LineDoubles
+)
+
+func (k LineKind) String() string {
+	switch k {
+	case LineSingles:
+		return "Singles"
+	case LineDoubles:
+		return "Doubles"
+	default:
+		return "Unknown"
+	}
+}
+
+// Side identifies the home or visiting side of a match.
+type Side int
+
+const (
+	SideUnknown Side = iota
+	SideHome
+	SideVisiting
+)
+
+// SetScore is the score of a single set, from the home side's perspective.
+type SetScore struct {
+	Home     int
+	Visiting int
 }
 
 // Line represents and individual line within a match.
 type Line struct {
+	Number int
+	Kind   LineKind
+
 	HomePlayer1 Player
 	HomePlayer2 Player
 
 	AwayPlayer1 Player
 	AwayPlayer2 Player
 
+	// WinnerScore is the score as shown on the scorecard, e.g. "6-3, 4-6, 1-0".
 	WinnerScore string
-	WinningTeam Team
+	Sets        []SetScore
+	Winner      Side
+	WinningTeam *Team
 }
 
-func (m *Match) ForOrganization(forOrg *Organization) (date time.Time, first string, outcome string, locator string, second string) {
-	// Formatting runs after all data is loaded; use Background context since
-	// organizations will already be cached and no new HTTP calls are expected.
-	ctx := context.Background()
-	m.HomeTeam.LoadOrganization(ctx)
-	m.VisitingTeam.LoadOrganization(ctx)
+// HomePlayers returns the home side's players for the line.
+func (l Line) HomePlayers() []Player {
+	return linePlayers(l.Kind, l.HomePlayer1, l.HomePlayer2)
+}
+
+// AwayPlayers returns the visiting side's players for the line.
+func (l Line) AwayPlayers() []Player {
+	return linePlayers(l.Kind, l.AwayPlayer1, l.AwayPlayer2)
+}
+
+func linePlayers(kind LineKind, p1, p2 Player) []Player {
+	if kind == LineSingles || p2.LastName == "" && p2.ID == 0 {
+		return []Player{p1}
+	}
+	return []Player{p1, p2}
+}
+
+// LoadLines loads the per-line results from the match's scorecard. It is a
+// no-op for matches without a scorecard.
+func (m *Match) LoadLines(ctx context.Context) error {
+	if m.ScorecardID == 0 || m.Lines != nil {
+		return nil
+	}
 
+	lines, err := LoadScorecard(ctx, m.ScorecardID)
+	if err != nil {
+		return err
+	}
+
+	m.Lines = make([]Line, len(lines))
+	for i, l := range lines {
+		switch l.Winner {
+		case SideHome:
+			l.WinningTeam = m.HomeTeam
+		case SideVisiting:
+			l.WinningTeam = m.VisitingTeam
+		}
+		m.Lines[i] = l
+	}
+
+	return nil
+}
+
+// ForOrganization describes the match from forOrg's side. A team whose
+// organization isn't loaded is described by its own name.
+func (m *Match) ForOrganization(forOrg *Organization) (date time.Time, first string, outcome string, locator string, second string) {
 	isOurs := func(t *Team) bool {
 		return t.Organization.Equals(forOrg) || t.Extra
 	}
@@ -64,11 +216,15 @@ func (m *Match) ForOrganization(forOrg *Organization) (date time.Time, first str
 
 	date = m.Date
 
-	first = firstTeam.Organization.ShortName() + " " + firstTeam.ShortName()
-	second = cases.Title(language.English).String(strings.ToLower(secondTeam.Organization.Name))
+	first = firstTeam.ShortName()
+	if firstTeam.Organization != nil {
+		first = firstTeam.Organization.ShortName() + " " + first
+	}
+	second = secondTeam.Name
+	if secondTeam.Organization != nil {
+		second = cases.Title(language.English).String(strings.ToLower(secondTeam.Organization.Name))
+	}
 	if m.Outcome.WinningTeam != nil {
-		m.Outcome.WinningTeam.LoadOrganization(ctx)
-
 		if m.Outcome.WinningTeam == firstTeam {
 			outcome = fmt.Sprintf("won %d - %d", m.Outcome.WinnerPoints, m.Outcome.LoserPoints)
 		} else {
@@ -0,0 +1,64 @@
+package usta
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MatchType is the stage of the season a match belongs to.
+type MatchType int
+
+const (
+	MatchTypeUnknown MatchType = iota
+	MatchTypeRegular
+	MatchTypePlayoff
+	MatchTypeSectionals
+)
+
+func (t MatchType) String() string {
+	switch t {
+	case MatchTypeRegular:
+		return "regular"
+	case MatchTypePlayoff:
+		return "playoff"
+	case MatchTypeSectionals:
+		return "sectionals"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	// sectionalsRegex matches match-number or flight text for Sectionals and
+	// other post-playoff championships.
+	sectionalsRegex = regexp.MustCompile(`(?i)\b(sectionals?|districts?|championships?|nationals?)\b`)
+	// playoffRegex matches match-number or flight text for local playoffs,
+	// e.g. "PO#1", "Semi-Final" or "Wildcard".
+	playoffRegex = regexp.MustCompile(`(?i)\b(playoffs?|po\s*#?\d*|semi[- ]?finals?|finals?|wild\s*card|tie\s*break(er)?)\b`)
+	// regularSeasonRegex matches the round-robin and week-range markers of
+	// regular-season match numbers, e.g. "2 (4/13-4/19) Full RR#1".
+	regularSeasonRegex = regexp.MustCompile(`(?i)\brr\s*#?\d*\b|round\s+robin|\(\d{1,2}/\d{1,2}\s*-\s*\d{1,2}/\d{1,2}\)`)
+)
+
+// classifyMatch determines the match type from the match-number cell text,
+// the team's flight name and the raw time cell text. Sectional matches are
+// scheduled with bare "HH:MM:SS" or "H:MMam" times rather than the usual
+// "All 3 at 7:30 PM" form, but local playoffs can be too, so explicit
+// playoff text takes precedence over the time format. It returns
+// MatchTypeUnknown when nothing in the text gives the type away.
+func classifyMatch(matchNumText, flightName, timeText string) MatchType {
+	timeText = strings.TrimSpace(timeText)
+
+	switch {
+	case sectionalsRegex.MatchString(matchNumText), sectionalsRegex.MatchString(flightName):
+		return MatchTypeSectionals
+	case playoffRegex.MatchString(matchNumText), playoffRegex.MatchString(flightName):
+		return MatchTypePlayoff
+	case bareTimeRegex.MatchString(timeText), bareAMPMTimeRegex.MatchString(timeText):
+		return MatchTypeSectionals
+	case regularSeasonRegex.MatchString(matchNumText):
+		return MatchTypeRegular
+	default:
+		return MatchTypeUnknown
+	}
+}
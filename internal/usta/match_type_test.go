@@ -0,0 +1,33 @@
+package usta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyMatch(t *testing.T) {
+	cases := map[string]struct {
+		matchNum   string
+		flightName string
+		time       string
+		expected   MatchType
+	}{
+		"round robin":      {"2 (4/13-4/19) Full RR#1", "4.0 Flight 1", "All 3 at 7:30 PM", MatchTypeRegular},
+		"week range":       {"5 (5/4-5/10)", "4.0 Flight 1", "All 3 at 7:30 PM", MatchTypeRegular},
+		"playoff number":   {"PO#1", "4.0 Flight 1", "All 3 at 7:30 PM", MatchTypePlayoff},
+		"semi-final":       {"Semi-Final", "4.0 Flight 1", "All 3 at 7:30 PM", MatchTypePlayoff},
+		"playoff flight":   {"1", "4.0 Playoffs", "All 3 at 7:30 PM", MatchTypePlayoff},
+		"sectionals text":  {"Sectionals R1", "4.0", "All 3 at 7:30 PM", MatchTypeSectionals},
+		"sectionals time":  {"1", "4.0", "08:00:00", MatchTypeSectionals},
+		"sectionals am/pm": {"1", "4.0", "8:00am", MatchTypeSectionals},
+		"playoff at time":  {"PO#1", "4.0 Flight 1", "08:00:00", MatchTypePlayoff},
+		"unknown":          {"3", "4.0 Flight 1", "All 3 at 7:30 PM", MatchTypeUnknown},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, classifyMatch(test.matchNum, test.flightName, test.time))
+		})
+	}
+}
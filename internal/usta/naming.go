@@ -0,0 +1,116 @@
+package usta
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LabelStyle decides what our own teams are called in the newsletter, after
+// the gender emoji.
+type LabelStyle string
+
+const (
+	// LabelLevel labels a team by its level, e.g. "3.5".
+	LabelLevel LabelStyle = "level"
+	// LabelAgeLevel labels a team by its age division and level, e.g.
+	// "40+ 3.5".
+	LabelAgeLevel LabelStyle = "age-level"
+	// LabelNickname labels a team by the nickname in brackets at the end of
+	// its code, e.g. "Team Bee's" for "ALMADEN SR 18AW3.5A [Team Bee's]".
+	LabelNickname LabelStyle = "nickname"
+	// LabelCaptain labels a team by its captain's last name.
+	LabelCaptain LabelStyle = "captain"
+)
+
+// NamingPolicy decides how our own teams are labelled. Teams without a
+// nickname or captain fall back to their level.
+type NamingPolicy struct {
+	Label LabelStyle `yaml:"label"`
+	// Teams overrides Label for individual teams, keyed by team ID.
+	Teams map[int]LabelStyle `yaml:"teams"`
+}
+
+// DefaultNamingPolicy labels every team by its level.
+var DefaultNamingPolicy = NamingPolicy{Label: LabelLevel}
+
+// namingPolicy is the policy TeamDisplay.Label follows.
+var namingPolicy = DefaultNamingPolicy
+
+// SetNamingPolicy sets the policy our own teams are labelled by.
+func SetNamingPolicy(p NamingPolicy) {
+	namingPolicy = p
+}
+
+// LoadNamingPolicy reads a naming policy from the YAML file at path. A
+// missing file yields DefaultNamingPolicy.
+func LoadNamingPolicy(path string) (NamingPolicy, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultNamingPolicy, nil
+	}
+	if err != nil {
+		return NamingPolicy{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	p := DefaultNamingPolicy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return NamingPolicy{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := p.Label.validate(); err != nil {
+		return NamingPolicy{}, fmt.Errorf("%s: label: %w", path, err)
+	}
+	for id, style := range p.Teams {
+		if err := style.validate(); err != nil {
+			return NamingPolicy{}, fmt.Errorf("%s: team %d: %w", path, id, err)
+		}
+	}
+	return p, nil
+}
+
+func (s LabelStyle) validate() error {
+	switch s {
+	case LabelLevel, LabelAgeLevel, LabelNickname, LabelCaptain:
+		return nil
+	default:
+		return fmt.Errorf("unknown label style %q (use level, age-level, nickname or captain)", s)
+	}
+}
+
+// style returns the label style for team id.
+func (p NamingPolicy) style(id int) LabelStyle {
+	if s, ok := p.Teams[id]; ok {
+		return s
+	}
+	if p.Label == "" {
+		return LabelLevel
+	}
+	return p.Label
+}
+
+// nicknameRegex matches the nickname in brackets (or, on some teams,
+// parentheses) at the end of a team code.
+var nicknameRegex = regexp.MustCompile(`(?:\[([^\]]+)\]|\(([^)]+)\))\s*$`)
+
+func parseNickname(code string) string {
+	m := nicknameRegex.FindStringSubmatch(code)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(m[1] + m[2])
+}
+
+// lastName returns the last name from a "First Last" or "Last, First" name.
+func lastName(name string) string {
+	if last, _, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(last)
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
@@ -0,0 +1,277 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	organizationListURL = "https://leagues.ustanorcal.com/orglist.asp"
+)
+
+// OrganizationSummary is one organization in the NorCal organization
+// listing.
+type OrganizationSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Teams is the number of teams the organization has this season, or 0 if
+	// the listing doesn't say.
+	Teams int `json:"teams"`
+}
+
+// ShortName returns the organization's short name, e.g. "ASRC".
+func (s OrganizationSummary) ShortName() string {
+	return (&Organization{Name: s.Name}).ShortName()
+}
+
+// LoadOrganizationList loads the listing of every NorCal organization.
+func LoadOrganizationList(ctx context.Context) ([]OrganizationSummary, error) {
+	const cacheKey = "orglist"
+
+	// Use singleflight to deduplicate concurrent requests
+	result, err, _ := orgListGroup.Do(cacheKey, func() (interface{}, error) {
+		if cached, ok := orgListCache.get(cacheKey); ok {
+			slog.Debug("organization list cache hit")
+			return cached.([]OrganizationSummary), nil
+		}
+
+		slog.Debug("fetching organization list page", "url", organizationListURL)
+		doc, err := fetchDocument(ctx, PageOrganizationList, organizationListURL)
+		if err != nil {
+			return nil, err
+		}
+
+		orgs := parseOrganizationList(doc)
+		orgListCache.set(cacheKey, orgs)
+
+		return orgs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]OrganizationSummary), nil
+}
+
+// parseOrganizationList extracts every organization link from the listing,
+// along with the team count from the same row if there is one.
+func parseOrganizationList(doc *goquery.Document) []OrganizationSummary {
+	var orgs []OrganizationSummary
+	seen := map[int]bool{}
+
+	doc.Find("a[href*='organization.asp?']").Each(func(i int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		id, err := parseIDParam(href)
+		if err != nil || id == 0 || seen[id] {
+			return
+		}
+		seen[id] = true
+
+		s := OrganizationSummary{ID: id, Name: cellText(link)}
+		if col := teamsColumn(link.Closest("table")); col >= 0 {
+			cell := link.Closest("tr").ChildrenFiltered("td").Eq(col)
+			s.Teams, _ = strconv.Atoi(cellText(cell))
+		}
+		orgs = append(orgs, s)
+	})
+
+	return orgs
+}
+
+// teamsColumn returns the index of the column headed "Teams" in the listing
+// table, or -1 if there isn't one.
+func teamsColumn(table *goquery.Selection) int {
+	col := -1
+	table.Find("tr").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		headers := row.ChildrenFiltered("th")
+		if headers.Length() == 0 {
+			return true
+		}
+		headers.EachWithBreak(func(j int, th *goquery.Selection) bool {
+			if strings.Contains(strings.ToLower(cellText(th)), "teams") {
+				col = j
+				return false
+			}
+			return true
+		})
+		return false
+	})
+	return col
+}
+
+// SearchOrganizations returns the organizations whose names match query,
+// best matches first. Matching ignores case and punctuation; each word of the
+// query must start a word of the name ("almaden swim"), allowing one typo in
+// longer words. The query also matches an organization's short name
+// ("ASRC").
+func SearchOrganizations(orgs []OrganizationSummary, query string) []OrganizationSummary {
+	matches := scoreOrganizations(orgs, query)
+	result := make([]OrganizationSummary, len(matches))
+	for i, m := range matches {
+		result[i] = m.org
+	}
+	return result
+}
+
+// ResolveOrganization returns the organization query means: the one named
+// query, ignoring case, if there is one, or else the only organization
+// SearchOrganizations matches. If no organization or more than one matches,
+// the error says so, listing the matches.
+func ResolveOrganization(orgs []OrganizationSummary, query string) (OrganizationSummary, error) {
+	var exact []OrganizationSummary
+	for _, o := range orgs {
+		if strings.EqualFold(strings.TrimSpace(o.Name), strings.TrimSpace(query)) {
+			exact = append(exact, o)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+
+	matches := SearchOrganizations(orgs, query)
+	switch len(matches) {
+	case 0:
+		return OrganizationSummary{}, fmt.Errorf("no organization matches %q", query)
+	case 1:
+		return matches[0], nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d organizations; use one of their IDs:", query, len(matches))
+	for _, o := range matches {
+		fmt.Fprintf(&b, "\n  %d\t%s", o.ID, o.Name)
+	}
+	return OrganizationSummary{}, fmt.Errorf("%s", b.String())
+}
+
+type scoredOrganization struct {
+	org   OrganizationSummary
+	score int
+}
+
+// scoreOrganizations returns the organizations matching query with their
+// scores, best first.
+func scoreOrganizations(orgs []OrganizationSummary, query string) []scoredOrganization {
+	q := searchWords(query)
+	if len(q) == 0 {
+		return nil
+	}
+
+	var matches []scoredOrganization
+	for _, o := range orgs {
+		if score := matchScore(o, q); score > 0 {
+			matches = append(matches, scoredOrganization{o, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].org.Name < matches[j].org.Name
+	})
+	return matches
+}
+
+// matchScore scores how well an organization matches the query words: 4 for
+// the whole name, 3 for its short name, 2 if every word starts a word of the
+// name, 1 if that's true allowing typos, and 0 for no match.
+func matchScore(o OrganizationSummary, q []string) int {
+	name := searchWords(o.Name)
+	switch {
+	case strings.Join(name, " ") == strings.Join(q, " "):
+		return 4
+	case len(q) == 1 && (q[0] == acronym(name) || q[0] == strings.ToLower(o.ShortName())):
+		return 3
+	}
+
+	exact := true
+	for _, w := range q {
+		found, foundExact := false, false
+		for _, n := range name {
+			if strings.HasPrefix(n, w) {
+				found, foundExact = true, true
+				break
+			}
+			if len(w) >= 4 && typoPrefix(w, n) {
+				found = true
+			}
+		}
+		if !found {
+			return 0
+		}
+		exact = exact && foundExact
+	}
+
+	if exact {
+		return 2
+	}
+	return 1
+}
+
+// searchWords lower-cases s, spells out "&" and splits it into words,
+// dropping punctuation.
+func searchWords(s string) []string {
+	s = strings.ReplaceAll(strings.ToLower(s), "&", " and ")
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// acronym returns the initials of words, skipping "and".
+func acronym(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if w != "and" {
+			b.WriteByte(w[0])
+		}
+	}
+	return b.String()
+}
+
+// typoPrefix reports whether w is within one edit of the start of word.
+func typoPrefix(w, word string) bool {
+	for n := len(w) - 1; n <= len(w)+1 && n <= len(word); n++ {
+		if withinOneEdit(w, word[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinOneEdit reports whether a and b differ by at most one insertion,
+// deletion or substitution.
+func withinOneEdit(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(a) == len(b) {
+			i++
+		}
+		j++
+	}
+	return edits+(len(b)-j)-(len(a)-i) <= 1
+}
@@ -0,0 +1,74 @@
+package usta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
+)
+
+const orgListHTML = `<html><body><table>
+<tr><th>Organization</th><th>Area</th><th>Teams</th></tr>
+<tr><td><a href="organization.asp?id=225">ALMADEN SWIM &amp; RACQUET CLUB</a></td><td>South Bay</td><td>14</td></tr>
+<tr><td><a href="organization.asp?id=300">ALMADEN VALLEY ATHLETIC CLUB</a></td><td>South Bay</td><td>9</td></tr>
+<tr><td><a href="organization.asp?id=412">BAY CLUB COURTSIDE</a></td><td>South Bay</td><td></td></tr>
+<tr><td><a href="organization.asp?id=225">ALMADEN SWIM &amp; RACQUET CLUB</a></td><td>South Bay</td><td>14</td></tr>
+</table></body></html>`
+
+// orgListNumberedHTML has a number column before the team count.
+const orgListNumberedHTML = `<html><body><table>
+<tr><th>#</th><th>Organization</th><th>Courts</th><th>Teams</th></tr>
+<tr><td>1</td><td><a href="organization.asp?id=225">ALMADEN SWIM &amp; RACQUET CLUB</a></td><td>12</td><td>14</td></tr>
+<tr><td>2</td><td><a href="organization.asp?id=412">BAY CLUB COURTSIDE</a></td><td>8</td><td></td></tr>
+</table></body></html>`
+
+func TestParseOrganizationList(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(orgListHTML))
+	require.NoError(t, err)
+
+	orgs := parseOrganizationList(doc)
+	require.Equal(t, []OrganizationSummary{
+		{ID: 225, Name: "ALMADEN SWIM & RACQUET CLUB", Teams: 14},
+		{ID: 300, Name: "ALMADEN VALLEY ATHLETIC CLUB", Teams: 9},
+		{ID: 412, Name: "BAY CLUB COURTSIDE"},
+	}, orgs)
+
+	doc, err = goquery.NewDocumentFromReader(strings.NewReader(orgListNumberedHTML))
+	require.NoError(t, err)
+	require.Equal(t, []OrganizationSummary{
+		{ID: 225, Name: "ALMADEN SWIM & RACQUET CLUB", Teams: 14},
+		{ID: 412, Name: "BAY CLUB COURTSIDE"},
+	}, parseOrganizationList(doc))
+}
+
+func TestSearchOrganizations(t *testing.T) {
+	orgs := []OrganizationSummary{
+		{ID: 225, Name: "ALMADEN SWIM & RACQUET CLUB"},
+		{ID: 300, Name: "ALMADEN VALLEY ATHLETIC CLUB"},
+		{ID: 412, Name: "BAY CLUB COURTSIDE"},
+	}
+
+	id := func(query string) int {
+		o, err := ResolveOrganization(orgs, query)
+		require.NoError(t, err)
+		return o.ID
+	}
+
+	// An exact name wins even though it starts other names too.
+	require.Equal(t, 412, id("bay club"))
+	// So does the only match.
+	require.Equal(t, 225, id("asrc"))
+	require.Equal(t, 225, id("almaden swim and racquet club"))
+
+	// Every match counts, not just the best ones.
+	_, err := ResolveOrganization(orgs, "almaden")
+	require.ErrorContains(t, err, "matches 2 organizations")
+	require.ErrorContains(t, err, "225\tALMADEN SWIM & RACQUET CLUB")
+	require.ErrorContains(t, err, "300\tALMADEN VALLEY ATHLETIC CLUB")
+	_, err = ResolveOrganization(append(orgs, OrganizationSummary{ID: 501, Name: "ASRC JUNIORS"}), "asrc")
+	require.ErrorContains(t, err, "matches 2 organizations")
+
+	_, err = ResolveOrganization(orgs, "stanford")
+	require.ErrorContains(t, err, "no organization matches")
+}
@@ -4,14 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/pkg/errors"
 )
 
 const (
@@ -32,6 +30,29 @@ type Organization struct {
 	Teams   []*Team `json:"teams"`
 
 	doc *goquery.Document
+	// loc is the time zone of the organization's league, if not the USTA
+	// NorCal one.
+	loc *time.Location
+}
+
+// location returns the time zone of the organization's league.
+func (o *Organization) location() *time.Location {
+	if o.loc != nil {
+		return o.loc
+	}
+	return tz
+}
+
+// Boundary returns the start of boundary's day in the league's time zone, or
+// of tomorrow if boundary is zero: the time dividing past matches from
+// upcoming ones.
+func (o *Organization) Boundary(boundary time.Time) time.Time {
+	loc := o.location()
+	if boundary.IsZero() {
+		now := time.Now().In(loc)
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(boundary.Year(), boundary.Month(), boundary.Day(), 0, 0, 0, 0, loc)
 }
 
 // LoadOrganization loads the organization details for the given organization ID.
@@ -47,22 +68,9 @@ func LoadOrganization(ctx context.Context, id int) (*Organization, error) {
 
 		url := fmt.Sprintf(organizationURL, id)
 		slog.Debug("fetching organization page", "org_id", id, "url", url)
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not create organization request")
-		}
-		res, err := httpClient.Do(req)
+		doc, err := fetchDocument(ctx, PageOrganization, url)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not fetch organization page")
-		}
-		defer res.Body.Close()
-		if res.StatusCode != 200 {
-			return nil, fmt.Errorf("error fetching organization page, code: %d", res.StatusCode)
-		}
-
-		doc, err := goquery.NewDocumentFromReader(res.Body)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not read organization page")
+			return nil, err
 		}
 
 		o := new(Organization)
@@ -84,6 +92,10 @@ func LoadOrganization(ctx context.Context, id int) (*Organization, error) {
 
 // LoadTeams loads teams for an organization.
 func (o *Organization) LoadTeams(ctx context.Context) (*Organization, error) {
+	if o.doc == nil {
+		return o, nil
+	}
+
 	var teamIDs []int
 
 	o.doc.Find("a").Each(func(i int, sel *goquery.Selection) {
@@ -110,6 +122,12 @@ func (o *Organization) LoadTeams(ctx context.Context) (*Organization, error) {
 			defer wg.Done()
 			t, err := LoadTeam(ctx, teamID)
 			if err != nil {
+				ReportFailure(ctx, LoadFailure{
+					What: "team",
+					ID:   teamID,
+					URL:  fmt.Sprintf(teamURL, teamID),
+					Err:  err,
+				})
 				return
 			}
 			mu.Lock()
@@ -141,7 +159,7 @@ func (o *Organization) ShortName() string {
 }
 
 func (o *Organization) LoadAddress() {
-	if o.Address != "" {
+	if o.Address != "" || o.doc == nil {
 		return
 	}
 
@@ -162,24 +180,48 @@ func (o *Organization) LoadAddress() {
 	o.Address = strings.Join(lines, ", ")
 }
 
+// HasTeam reports whether the team with the given ID is one of o's teams.
+func (o *Organization) HasTeam(id int) bool {
+	for _, t := range o.Teams {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Equals reports whether o and ao are the same organization. An unknown
+// (nil) organization equals none.
 func (o *Organization) Equals(ao *Organization) bool {
-	return o.ID == ao.ID
+	return o != nil && ao != nil && o.ID == ao.ID
 }
 
 func (o *Organization) Matches(past, future time.Duration, boundary time.Time) (pastMatches []Match, futureMatches []Match) {
-	if boundary.IsZero() {
-		now := time.Now().In(tz)
-		boundary = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, tz)
-	} else {
-		boundary = time.Date(boundary.Year(), boundary.Month(), boundary.Day(), 0, 0, 0, 0, tz)
-	}
+	boundary = o.Boundary(boundary)
 	pastStart := boundary.Add(-1 * past)
 	futureEnd := boundary.Add(future)
 
 	slog.Info("match date range", "past_start", pastStart.Format("2006-01-02"), "boundary", boundary.Format("2006-01-02"), "future_end", futureEnd.Format("2006-01-02"))
 
+	orgTeamIDs := make(map[int]bool, len(o.Teams))
+	for _, t := range o.Teams {
+		orgTeamIDs[t.ID] = true
+	}
+
+	// A match between two of our own teams is on both teams' schedules, so
+	// only keep the copy from the first team's schedule.
+	seenFrom := make(map[string]int)
 	for _, t := range o.Teams {
 		for _, m := range t.Matches {
+			if orgTeamIDs[m.HomeTeam.ID] && orgTeamIDs[m.VisitingTeam.ID] {
+				key := m.Key()
+				if from, ok := seenFrom[key]; ok && from != t.ID {
+					continue
+				}
+				seenFrom[key] = t.ID
+				m.Derby = true
+			}
+
 			if !m.Date.Before(boundary) && m.Date.Before(futureEnd) {
 				// Upcoming matches need a known time to display, so skip
 				// ones where the time couldn't be determined.
@@ -199,10 +241,6 @@ func (o *Organization) Matches(past, future time.Duration, boundary time.Time) (
 	sort.Slice(pastMatches, func(i, j int) bool {
 		return pastMatches[i].Date.Before(pastMatches[j].Date)
 	})
-	orgTeamIDs := make(map[int]bool, len(o.Teams))
-	for _, t := range o.Teams {
-		orgTeamIDs[t.ID] = true
-	}
 	sort.Slice(futureMatches, func(i, j int) bool {
 		if !futureMatches[i].Date.Equal(futureMatches[j].Date) {
 			return futureMatches[i].Date.Before(futureMatches[j].Date)
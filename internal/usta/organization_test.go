@@ -44,3 +44,59 @@ func TestOrganizationMatchesIncludesPastMatchesWithoutTime(t *testing.T) {
 	require.Len(t, future, 1)
 	require.True(t, future[0].HasTime)
 }
+
+func TestOrganizationMatchesDeduplicatesDerbies(t *testing.T) {
+	boundary := time.Date(2026, 7, 12, 0, 0, 0, 0, tz)
+
+	teamA := &Team{ID: 1, FlightID: 7}
+	teamB := &Team{ID: 2, FlightID: 7}
+	opponent := &Team{ID: 3, FlightID: 7}
+
+	derby := Match{
+		Number:       2,
+		Date:         time.Date(2026, 7, 10, 18, 30, 0, 0, tz),
+		HasTime:      true,
+		HomeTeam:     teamA,
+		VisitingTeam: teamB,
+	}
+	other := Match{
+		Number:       3,
+		Date:         time.Date(2026, 7, 11, 18, 30, 0, 0, tz),
+		HasTime:      true,
+		HomeTeam:     teamA,
+		VisitingTeam: opponent,
+	}
+
+	teamA.Matches = []Match{derby, other}
+	teamB.Matches = []Match{derby}
+
+	org := &Organization{Teams: []*Team{teamA, teamB}}
+
+	past, _ := org.Matches(7*24*time.Hour, 7*24*time.Hour, boundary)
+
+	require.Len(t, past, 2)
+	require.True(t, past[0].Derby)
+	require.False(t, past[1].Derby)
+}
+
+func TestOrganizationMatchesWindowsInLeagueTimeZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	defer SetTimeZone(TimeZone())
+	SetTimeZone(ny)
+
+	teamA := &Team{ID: 1}
+	teamB := &Team{ID: 2}
+	// 1am on 7/12 in New York is still 7/11 in California.
+	teamA.Matches = []Match{{
+		Date:         time.Date(2026, 7, 12, 1, 0, 0, 0, ny),
+		HasTime:      true,
+		HomeTeam:     teamA,
+		VisitingTeam: teamB,
+	}}
+	org := &Organization{Teams: []*Team{teamA}}
+
+	past, future := org.Matches(7*24*time.Hour, 7*24*time.Hour, time.Date(2026, 7, 12, 0, 0, 0, 0, ny))
+	require.Empty(t, past)
+	require.Len(t, future, 1)
+}
@@ -0,0 +1,175 @@
+package usta
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pageStore is a directory of raw page bodies keyed by URL, each stored with
+// its metadata. The disk cache and page archives both keep their pages in
+// one.
+type pageStore struct {
+	dir string
+
+	mu sync.Mutex
+}
+
+// pageMeta is stored alongside each page body.
+type pageMeta struct {
+	URL          string    `json:"url"`
+	Kind         PageKind  `json:"kind"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// storedPage is a page read back from a pageStore.
+type storedPage struct {
+	meta pageMeta
+	body []byte
+}
+
+// pageEntryRegex matches the file names of stored pages and their metadata.
+var pageEntryRegex = regexp.MustCompile(`^[0-9a-f]{64}\.(?:html|json)$`)
+
+func (s *pageStore) paths(u string) (body, meta string) {
+	sum := sha256.Sum256([]byte(u))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(s.dir, key+".html"), filepath.Join(s.dir, key+".json")
+}
+
+// get returns the stored page for u, or nil if the page isn't stored.
+func (s *pageStore) get(u string) (*storedPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bodyPath, metaPath := s.paths(u)
+	b, err := os.ReadFile(metaPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading page metadata for %s: %w", u, err)
+	}
+	var meta pageMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, fmt.Errorf("reading page metadata for %s: %w", u, err)
+	}
+	if meta.URL != u {
+		return nil, nil
+	}
+
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading stored page %s: %w", u, err)
+	}
+	return &storedPage{meta: meta, body: body}, nil
+}
+
+// put stores a page body with its metadata.
+func (s *pageStore) put(p *storedPage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", s.dir, err)
+	}
+
+	bodyPath, metaPath := s.paths(p.meta.URL)
+	if err := os.WriteFile(bodyPath, p.body, 0644); err != nil {
+		return fmt.Errorf("writing stored page: %w", err)
+	}
+	return writePageMeta(metaPath, p.meta)
+}
+
+// putMeta replaces the metadata of a stored page.
+func (s *pageStore) putMeta(meta pageMeta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, metaPath := s.paths(meta.URL)
+	return writePageMeta(metaPath, meta)
+}
+
+func writePageMeta(path string, meta pageMeta) error {
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling page metadata: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("writing page metadata: %w", err)
+	}
+	return nil
+}
+
+// list returns the metadata of every stored page, skipping any that can't be
+// read, and the total size of the store's files.
+func (s *pageStore) list() ([]pageMeta, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading directory %s: %w", s.dir, err)
+	}
+
+	var metas []pageMeta
+	var size int64
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !pageEntryRegex.MatchString(e.Name()) {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			size += info.Size()
+		}
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var meta pageMeta
+		if err := json.Unmarshal(b, &meta); err != nil {
+			continue
+		}
+		metas = append(metas, meta)
+	}
+	return metas, size, nil
+}
+
+// clear removes every stored page. Only the store's own files are removed,
+// so pointing it at a directory that holds anything else is safe.
+func (s *pageStore) clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading directory %s: %w", s.dir, err)
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !pageEntryRegex.MatchString(e.Name()) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, e.Name())); err != nil {
+			return fmt.Errorf("removing %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
@@ -7,4 +7,19 @@ type Player struct {
 
 	FirstName string
 	LastName  string
+
+	// Rating is the player's NTRP rating, e.g. "3.5", and RatingType its
+	// type, e.g. "C" for computer or "S" for self-rated.
+	Rating     string
+	RatingType string
+
+	Captain bool
+}
+
+// Name returns the player's full name.
+func (p Player) Name() string {
+	if p.FirstName == "" {
+		return p.LastName
+	}
+	return p.FirstName + " " + p.LastName
 }
@@ -0,0 +1,65 @@
+package usta
+
+import "context"
+
+// Provider supplies the league data a newsletter is built from:
+// organizations, their teams, and the teams' matches, rosters and flight
+// standings. The USTA NorCal site is one provider; a league file is another.
+type Provider interface {
+	// LoadOrganization returns the organization with the given ID, with its
+	// teams loaded.
+	LoadOrganization(ctx context.Context, id int) (*Organization, error)
+	// LoadTeam returns the team with the given ID.
+	LoadTeam(ctx context.Context, id int) (*Team, error)
+	// LoadMatches fills the team's matches.
+	LoadMatches(ctx context.Context, t *Team) error
+	// LoadRoster fills the team's players.
+	LoadRoster(ctx context.Context, t *Team) error
+	// LoadFlight fills the standings of the team's flight, if known.
+	LoadFlight(ctx context.Context, t *Team) error
+	// LoadTeamOrganization fills the team's organization, with its address.
+	LoadTeamOrganization(ctx context.Context, t *Team) error
+}
+
+// NorCalProvider loads league data by scraping leagues.ustanorcal.com.
+type NorCalProvider struct{}
+
+// NewNorCalProvider returns a provider for the USTA NorCal site.
+func NewNorCalProvider() *NorCalProvider {
+	return &NorCalProvider{}
+}
+
+func (p *NorCalProvider) LoadOrganization(ctx context.Context, id int) (*Organization, error) {
+	o, err := LoadOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return o.LoadTeams(ctx)
+}
+
+func (p *NorCalProvider) LoadTeam(ctx context.Context, id int) (*Team, error) {
+	return LoadTeam(ctx, id)
+}
+
+func (p *NorCalProvider) LoadMatches(ctx context.Context, t *Team) error {
+	_, err := t.LoadMatches(ctx)
+	return err
+}
+
+func (p *NorCalProvider) LoadRoster(ctx context.Context, t *Team) error {
+	_, err := t.LoadRoster(ctx)
+	return err
+}
+
+func (p *NorCalProvider) LoadFlight(ctx context.Context, t *Team) error {
+	_, err := t.LoadFlight(ctx)
+	return err
+}
+
+func (p *NorCalProvider) LoadTeamOrganization(ctx context.Context, t *Team) error {
+	if _, err := t.LoadOrganization(ctx); err != nil {
+		return err
+	}
+	t.Organization.LoadAddress()
+	return nil
+}
@@ -0,0 +1,282 @@
+package usta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// leagueFile is the layout of a JSON or YAML league file.
+type leagueFile struct {
+	// TimeZone is the IANA time zone match times are given in, e.g.
+	// "America/New_York". It defaults to the league time zone set with
+	// SetTimeZone.
+	TimeZone      string               `json:"time_zone" yaml:"time_zone"`
+	Organizations []leagueOrganization `json:"organizations" yaml:"organizations"`
+	Teams         []leagueTeam         `json:"teams" yaml:"teams"`
+	Flights       []Flight             `json:"flights" yaml:"flights"`
+	Matches       []leagueMatch        `json:"matches" yaml:"matches"`
+}
+
+type leagueOrganization struct {
+	ID      int    `json:"id" yaml:"id"`
+	Name    string `json:"name" yaml:"name"`
+	Address string `json:"address" yaml:"address"`
+}
+
+type leagueTeam struct {
+	ID             int            `json:"id" yaml:"id"`
+	OrganizationID int            `json:"organization_id" yaml:"organization_id"`
+	Name           string         `json:"name" yaml:"name"`
+	Code           string         `json:"code" yaml:"code"`
+	FlightID       int            `json:"flight_id" yaml:"flight_id"`
+	FlightName     string         `json:"flight_name" yaml:"flight_name"`
+	Area           string         `json:"area" yaml:"area"`
+	CaptainName    string         `json:"captain_name" yaml:"captain_name"`
+	CaptainContact string         `json:"captain_contact" yaml:"captain_contact"`
+	Players        []leaguePlayer `json:"players" yaml:"players"`
+}
+
+type leaguePlayer struct {
+	FirstName string `json:"first_name" yaml:"first_name"`
+	LastName  string `json:"last_name" yaml:"last_name"`
+	Rating    string `json:"rating" yaml:"rating"`
+	Captain   bool   `json:"captain" yaml:"captain"`
+}
+
+type leagueMatch struct {
+	Number         int    `json:"number" yaml:"number"`
+	HomeTeamID     int    `json:"home_team_id" yaml:"home_team_id"`
+	VisitingTeamID int    `json:"visiting_team_id" yaml:"visiting_team_id"`
+	Date           string `json:"date" yaml:"date"` // "2006-01-02" or "2006-01-02 15:04"
+	Type           string `json:"type" yaml:"type"` // "regular", "playoff" or "sectionals"
+	// Outcome is "played" (the default when there's a winner), "default",
+	// "forfeit", "retired", "rainout" or "postponed".
+	Outcome      string `json:"outcome" yaml:"outcome"`
+	WinnerTeamID int    `json:"winner_team_id" yaml:"winner_team_id"`
+	Score        string `json:"score" yaml:"score"` // winner's points first, e.g. "3-2"
+}
+
+// FileProvider serves league data from a JSON or YAML league file, for
+// leagues that aren't on the USTA NorCal site.
+type FileProvider struct {
+	path string
+	loc  *time.Location
+	orgs map[int]*Organization
+	// teams holds every team in the file, whichever organization it's in.
+	teams map[int]*Team
+}
+
+// NewFileProvider loads the league file at path. Files ending in ".json" are
+// read as JSON; anything else is read as YAML.
+func NewFileProvider(path string) (*FileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading league file: %w", err)
+	}
+
+	var lf leagueFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &lf)
+	} else {
+		err = yaml.Unmarshal(data, &lf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing league file %s: %w", path, err)
+	}
+
+	p, err := newFileProvider(lf)
+	if err != nil {
+		return nil, fmt.Errorf("league file %s: %w", path, err)
+	}
+	p.path = path
+	return p, nil
+}
+
+// newFileProvider builds the organizations, teams and matches described by
+// a league file.
+func newFileProvider(lf leagueFile) (*FileProvider, error) {
+	p := &FileProvider{
+		loc:   tz,
+		orgs:  make(map[int]*Organization),
+		teams: make(map[int]*Team),
+	}
+
+	if lf.TimeZone != "" {
+		loc, err := time.LoadLocation(lf.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", lf.TimeZone, err)
+		}
+		p.loc = loc
+	}
+
+	for _, lo := range lf.Organizations {
+		p.orgs[lo.ID] = &Organization{ID: lo.ID, Name: lo.Name, Address: lo.Address, loc: p.loc}
+	}
+
+	flights := make(map[int]*Flight, len(lf.Flights))
+	for i := range lf.Flights {
+		flights[lf.Flights[i].ID] = &lf.Flights[i]
+	}
+
+	for _, lt := range lf.Teams {
+		o, ok := p.orgs[lt.OrganizationID]
+		if !ok {
+			return nil, fmt.Errorf("team %d: unknown organization %d", lt.ID, lt.OrganizationID)
+		}
+
+		t := &Team{
+			ID:             lt.ID,
+			Organization:   o,
+			Name:           lt.Name,
+			Code:           lt.Code,
+			Matches:        []Match{},
+			Players:        []Player{},
+			Flight:         flights[lt.FlightID],
+			League:         parseLeague(lt.Name),
+			AgeDivision:    parseAgeDivision(lt.Name),
+			Season:         parseSeason(lt.Name),
+			Area:           lt.Area,
+			FlightID:       lt.FlightID,
+			FlightName:     lt.FlightName,
+			CaptainName:    lt.CaptainName,
+			CaptainContact: lt.CaptainContact,
+		}
+		for _, lp := range lt.Players {
+			t.Players = append(t.Players, Player{
+				FirstName: lp.FirstName,
+				LastName:  lp.LastName,
+				Rating:    lp.Rating,
+				Captain:   lp.Captain,
+			})
+		}
+		t.captainFromRoster()
+
+		p.teams[t.ID] = t
+		o.Teams = append(o.Teams, t)
+	}
+
+	for i, lm := range lf.Matches {
+		m, err := p.buildMatch(lm)
+		if err != nil {
+			return nil, fmt.Errorf("match %d: %w", i+1, err)
+		}
+		m.HomeTeam.Matches = append(m.HomeTeam.Matches, m)
+		m.VisitingTeam.Matches = append(m.VisitingTeam.Matches, m)
+	}
+
+	return p, nil
+}
+
+func (p *FileProvider) buildMatch(lm leagueMatch) (Match, error) {
+	m := Match{
+		Number:       lm.Number,
+		HomeTeam:     p.teams[lm.HomeTeamID],
+		VisitingTeam: p.teams[lm.VisitingTeamID],
+	}
+	if m.HomeTeam == nil {
+		return m, fmt.Errorf("unknown home team %d", lm.HomeTeamID)
+	}
+	if m.VisitingTeam == nil {
+		return m, fmt.Errorf("unknown visiting team %d", lm.VisitingTeamID)
+	}
+
+	var err error
+	if m.Date, err = time.ParseInLocation("2006-01-02 15:04", lm.Date, p.loc); err == nil {
+		m.HasTime = true
+	} else if m.Date, err = time.ParseInLocation("2006-01-02", lm.Date, p.loc); err != nil {
+		return m, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or YYYY-MM-DD HH:MM", lm.Date)
+	}
+
+	switch strings.ToLower(lm.Type) {
+	case "", "regular":
+		m.Type = MatchTypeRegular
+	case "playoff":
+		m.Type = MatchTypePlayoff
+	case "sectionals":
+		m.Type = MatchTypeSectionals
+	default:
+		return m, fmt.Errorf("invalid type %q: expected regular, playoff or sectionals", lm.Type)
+	}
+
+	kind, ok := outcomeKinds[strings.ToLower(lm.Outcome)]
+	if !ok {
+		return m, fmt.Errorf("invalid outcome %q", lm.Outcome)
+	}
+	if kind == OutcomeUnknown && lm.WinnerTeamID != 0 {
+		kind = OutcomePlayed
+	}
+	m.Outcome.Kind = kind
+
+	if lm.WinnerTeamID != 0 {
+		switch lm.WinnerTeamID {
+		case lm.HomeTeamID:
+			m.Outcome.WinningTeam = m.HomeTeam
+		case lm.VisitingTeamID:
+			m.Outcome.WinningTeam = m.VisitingTeam
+		default:
+			return m, fmt.Errorf("winner %d is not playing in the match", lm.WinnerTeamID)
+		}
+		if lm.Score != "" {
+			if _, err := fmt.Sscanf(lm.Score, "%d-%d", &m.Outcome.WinnerPoints, &m.Outcome.LoserPoints); err != nil {
+				return m, fmt.Errorf("invalid score %q: expected e.g. 3-2", lm.Score)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// outcomeKinds maps the outcomes in a league file to their kinds.
+var outcomeKinds = map[string]OutcomeKind{
+	"":          OutcomeUnknown,
+	"played":    OutcomePlayed,
+	"default":   OutcomeDefault,
+	"forfeit":   OutcomeForfeit,
+	"retired":   OutcomeRetired,
+	"rainout":   OutcomeRainout,
+	"postponed": OutcomePostponed,
+}
+
+func (p *FileProvider) LoadOrganization(ctx context.Context, id int) (*Organization, error) {
+	o, ok := p.orgs[id]
+	if !ok {
+		return nil, fmt.Errorf("organization %d not found in league file %s", id, p.path)
+	}
+	return o, nil
+}
+
+func (p *FileProvider) LoadTeam(ctx context.Context, id int) (*Team, error) {
+	t, ok := p.teams[id]
+	if !ok {
+		return nil, fmt.Errorf("team %d not found in league file %s", id, p.path)
+	}
+	return t, nil
+}
+
+// LoadMatches is a no-op: matches are read along with the file.
+func (p *FileProvider) LoadMatches(ctx context.Context, t *Team) error {
+	return nil
+}
+
+// LoadRoster is a no-op: rosters are read along with the file.
+func (p *FileProvider) LoadRoster(ctx context.Context, t *Team) error {
+	return nil
+}
+
+// LoadFlight is a no-op: flight standings are read along with the file.
+func (p *FileProvider) LoadFlight(ctx context.Context, t *Team) error {
+	return nil
+}
+
+// LoadTeamOrganization is a no-op: every team's organization is read along
+// with the file.
+func (p *FileProvider) LoadTeamOrganization(ctx context.Context, t *Team) error {
+	return nil
+}
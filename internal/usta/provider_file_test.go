@@ -0,0 +1,99 @@
+package usta
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLeagueYAML = `
+time_zone: America/New_York
+organizations:
+  - id: 1
+    name: RIVERSIDE TENNIS CLUB
+  - id: 2
+    name: HILLTOP RACQUET CLUB
+teams:
+  - id: 10
+    organization_id: 1
+    name: 2026 Adult 18 & Over Womens 3.5
+    code: RIVERSIDE 18AW3.5
+    flight_id: 5
+    players:
+      - {first_name: Jane, last_name: Smith, rating: "3.5", captain: true}
+  - id: 20
+    organization_id: 2
+    name: 2026 Adult 18 & Over Womens 3.5
+    code: HILLTOP 18AW3.5
+    flight_id: 5
+flights:
+  - id: 5
+    name: Flight 1
+    standings:
+      - {position: 1, team_id: 10, team_name: RIVERSIDE 18AW3.5, wins: 1, losses: 0}
+matches:
+  - number: 1
+    home_team_id: 10
+    visiting_team_id: 20
+    date: "2026-04-15 19:30"
+    winner_team_id: 10
+    score: 3-2
+  - number: 2
+    home_team_id: 20
+    visiting_team_id: 10
+    date: "2026-04-22"
+    type: playoff
+`
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "league.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testLeagueYAML), 0644))
+
+	p, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	o, err := p.LoadOrganization(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "RIVERSIDE TENNIS CLUB", o.Name)
+	require.Len(t, o.Teams, 1)
+
+	team := o.Teams[0]
+	require.NoError(t, p.LoadMatches(ctx, team))
+	require.Len(t, team.Matches, 2)
+	require.Equal(t, LeagueAdult, team.League)
+	require.Equal(t, "Jane Smith", team.CaptainName)
+	require.Equal(t, "Flight 1", team.Flight.Name)
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	played := team.Matches[0]
+	require.Equal(t, time.Date(2026, 4, 15, 19, 30, 0, 0, ny), played.Date)
+	require.True(t, played.HasTime)
+	require.Equal(t, OutcomePlayed, played.Outcome.Kind)
+	require.Same(t, team, played.Outcome.WinningTeam)
+	require.Equal(t, 3, played.Outcome.WinnerPoints)
+	require.Equal(t, "HILLTOP RACQUET CLUB", played.VisitingTeam.Organization.Name)
+
+	upcoming := team.Matches[1]
+	require.False(t, upcoming.HasTime)
+	require.Equal(t, MatchTypePlayoff, upcoming.Type)
+	require.Nil(t, upcoming.Outcome.WinningTeam)
+
+	_, err = p.LoadTeam(ctx, 99)
+	require.Error(t, err)
+}
+
+func TestFileProviderRejectsUnknownTeams(t *testing.T) {
+	_, err := newFileProvider(leagueFile{
+		Organizations: []leagueOrganization{{ID: 1, Name: "RIVERSIDE TENNIS CLUB"}},
+		Teams:         []leagueTeam{{ID: 10, OrganizationID: 1}},
+		Matches:       []leagueMatch{{HomeTeamID: 10, VisitingTeamID: 20, Date: "2026-04-15"}},
+	})
+	require.ErrorContains(t, err, "unknown visiting team 20")
+}
@@ -0,0 +1,121 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LoadFailure describes a page that could not be loaded or parsed, and why.
+type LoadFailure struct {
+	// What is the kind of thing that failed, e.g. "team", "opponent" or
+	// "scorecard".
+	What string
+	ID   int
+	// TeamID is the ID of the club team whose data is incomplete because of
+	// the failure, if different from ID.
+	TeamID int
+	URL    string
+	Err    error
+}
+
+func (f LoadFailure) String() string {
+	s := f.What
+	if f.ID != 0 {
+		s += fmt.Sprintf(" %d", f.ID)
+	}
+	if f.TeamID != 0 && f.TeamID != f.ID {
+		s += fmt.Sprintf(" (for team %d)", f.TeamID)
+	}
+	if f.Err != nil {
+		s += ": " + f.Err.Error()
+	}
+	return s
+}
+
+// LoadReport collects the failures encountered while loading an
+// organization's teams and matches. It is safe for concurrent use.
+type LoadReport struct {
+	mu       sync.Mutex
+	failures []LoadFailure
+}
+
+// NewLoadReport returns an empty LoadReport.
+func NewLoadReport() *LoadReport {
+	return &LoadReport{}
+}
+
+// Add records a failure.
+func (r *LoadReport) Add(f LoadFailure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, f)
+}
+
+// Failures returns the recorded failures, ordered by kind and ID.
+func (r *LoadReport) Failures() []LoadFailure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failures := make([]LoadFailure, len(r.failures))
+	copy(failures, r.failures)
+	sort.SliceStable(failures, func(i, j int) bool {
+		if failures[i].What != failures[j].What {
+			return failures[i].What < failures[j].What
+		}
+		return failures[i].ID < failures[j].ID
+	})
+	return failures
+}
+
+// Count returns the number of failures recorded so far.
+func (r *LoadReport) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.failures)
+}
+
+// HasFailures reports whether any failures were recorded.
+func (r *LoadReport) HasFailures() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.failures) > 0
+}
+
+// Err returns an error summarizing the failures, or nil if there were none.
+func (r *LoadReport) Err() error {
+	failures := r.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(failures))
+	for i, f := range failures {
+		lines[i] = "  " + f.String()
+	}
+	return fmt.Errorf("failed to load %d page(s):\n%s", len(failures), strings.Join(lines, "\n"))
+}
+
+type loadReportKey struct{}
+
+// WithLoadReport returns a context whose loaders record failures in r.
+func WithLoadReport(ctx context.Context, r *LoadReport) context.Context {
+	return context.WithValue(ctx, loadReportKey{}, r)
+}
+
+// LoadReportFrom returns the LoadReport attached to ctx, if any.
+func LoadReportFrom(ctx context.Context) *LoadReport {
+	r, _ := ctx.Value(loadReportKey{}).(*LoadReport)
+	return r
+}
+
+// ReportFailure logs f and records it in the context's LoadReport, if any.
+func ReportFailure(ctx context.Context, f LoadFailure) {
+	slog.Warn("failed to load", "what", f.What, "id", f.ID, "team_id", f.TeamID, "url", f.URL, "error", f.Err)
+	if r := LoadReportFrom(ctx); r != nil {
+		r.Add(f)
+	}
+}
@@ -0,0 +1,29 @@
+package usta
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadReport(t *testing.T) {
+	r := NewLoadReport()
+	require.False(t, r.HasFailures())
+	require.NoError(t, r.Err())
+
+	ctx := WithLoadReport(context.Background(), r)
+	ReportFailure(ctx, LoadFailure{What: "team", ID: 20, Err: errors.New("code: 503")})
+	ReportFailure(ctx, LoadFailure{What: "opponent", ID: 30, TeamID: 10, Err: errors.New("timeout")})
+
+	// Failures without a report in the context are only logged.
+	ReportFailure(context.Background(), LoadFailure{What: "team", ID: 40})
+
+	require.True(t, r.HasFailures())
+	failures := r.Failures()
+	require.Len(t, failures, 2)
+	require.Equal(t, "opponent 30 (for team 10): timeout", failures[0].String())
+	require.Equal(t, "team 20: code: 503", failures[1].String())
+	require.EqualError(t, r.Err(), "failed to load 2 page(s):\n  opponent 30 (for team 10): timeout\n  team 20: code: 503")
+}
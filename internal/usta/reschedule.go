@@ -0,0 +1,155 @@
+package usta
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ScheduleHistory remembers the date and time each match was last seen at,
+// so that matches moved since the previous run can be flagged. It is kept in
+// a JSON state file keyed by team ID and match number, or by team ID,
+// opponent ID and match type for matches without a number.
+type ScheduleHistory struct {
+	path string
+
+	mu sync.Mutex
+	// previous holds the state file as it was before this run; current
+	// holds every match seen during this run.
+	previous map[string]scheduleRecord
+	current  map[string]scheduleRecord
+}
+
+type scheduleRecord struct {
+	Date    time.Time `json:"date"`
+	HasTime bool      `json:"has_time"`
+	// RescheduledFrom is the date the match was at before it last moved,
+	// or zero if it has never moved.
+	RescheduledFrom time.Time `json:"rescheduled_from,omitzero"`
+}
+
+// scheduleHistory, if set, is consulted by ObserveSchedule to flag
+// rescheduled matches.
+var scheduleHistory *ScheduleHistory
+
+// SetScheduleHistory sets the history ObserveSchedule uses to flag
+// rescheduled matches. Pass nil to stop tracking reschedules.
+func SetScheduleHistory(h *ScheduleHistory) {
+	scheduleHistory = h
+}
+
+// ObserveSchedule records t's loaded matches in the schedule history and
+// sets the RescheduledFrom date of each one that has moved. It does nothing
+// if no history is set.
+func ObserveSchedule(t *Team) {
+	if scheduleHistory == nil {
+		return
+	}
+	for i := range t.Matches {
+		t.Matches[i].RescheduledFrom = scheduleHistory.observe(t.ID, t.Matches[i])
+	}
+}
+
+// LoadScheduleHistory reads the schedule state file at path. A missing file
+// is treated as empty, as on the first run.
+func LoadScheduleHistory(path string) (*ScheduleHistory, error) {
+	h := &ScheduleHistory{
+		path:     path,
+		previous: make(map[string]scheduleRecord),
+		current:  make(map[string]scheduleRecord),
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading schedule state file: %w", err)
+	}
+	if err := json.Unmarshal(b, &h.previous); err != nil {
+		return nil, fmt.Errorf("parsing schedule state file %s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Path returns the path of the state file.
+func (h *ScheduleHistory) Path() string {
+	return h.path
+}
+
+// observe records the match as seen on team's schedule and returns the date
+// it was rescheduled from, or zero if it hasn't moved. A match keeps its
+// "rescheduled from" date across runs until it moves again or is played.
+func (h *ScheduleHistory) observe(teamID int, m Match) time.Time {
+	key := scheduleKey(teamID, m)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rec := scheduleRecord{Date: m.Date, HasTime: m.HasTime}
+	if prev, ok := h.previous[key]; ok && m.Outcome.WinningTeam == nil {
+		if moved(prev, m) {
+			rec.RescheduledFrom = prev.Date
+		} else {
+			rec.RescheduledFrom = prev.RescheduledFrom
+		}
+	}
+	h.current[key] = rec
+
+	return rec.RescheduledFrom
+}
+
+// scheduleKey identifies the match on team's schedule across runs: by its
+// number or, for matches without one such as playoffs, by the opponent and
+// the match type, neither of which changes when the match moves.
+func scheduleKey(teamID int, m Match) string {
+	if m.Number != 0 {
+		return fmt.Sprintf("%d/%d", teamID, m.Number)
+	}
+	opponentID := m.HomeTeam.ID
+	if opponentID == teamID {
+		opponentID = m.VisitingTeam.ID
+	}
+	return fmt.Sprintf("%d/vs%d/%s", teamID, opponentID, m.Type)
+}
+
+// moved reports whether m is on a different day than prev, or at a different
+// time when both times are known. Gaining or losing a time is not a move.
+func moved(prev scheduleRecord, m Match) bool {
+	py, pm, pd := prev.Date.Date()
+	y, mo, d := m.Date.In(prev.Date.Location()).Date()
+	if py != y || pm != mo || pd != d {
+		return true
+	}
+	return prev.HasTime && m.HasTime && !prev.Date.Equal(m.Date)
+}
+
+// Save writes every match seen during this run, along with those from
+// previous runs that weren't seen this time, back to the state file.
+func (h *ScheduleHistory) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	all := make(map[string]scheduleRecord, len(h.previous)+len(h.current))
+	for k, v := range h.previous {
+		all[k] = v
+	}
+	for k, v := range h.current {
+		all[k] = v
+	}
+
+	b, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling schedule state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("creating schedule state directory: %w", err)
+	}
+	if err := os.WriteFile(h.path, b, 0644); err != nil {
+		return fmt.Errorf("writing schedule state file: %w", err)
+	}
+	return nil
+}
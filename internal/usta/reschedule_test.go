@@ -0,0 +1,126 @@
+package usta
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveScheduleFlagsRescheduledMatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	boundary := time.Date(2026, 4, 13, 0, 0, 0, 0, tz)
+
+	teamA := &Team{ID: 1}
+	teamB := &Team{ID: 2}
+	match := func(number, day, hour int) Match {
+		return Match{
+			Number:       number,
+			Date:         time.Date(2026, 4, day, hour, 0, 0, 0, tz),
+			HasTime:      true,
+			HomeTeam:     teamA,
+			VisitingTeam: teamB,
+		}
+	}
+	org := &Organization{Teams: []*Team{teamA}}
+
+	run := func(matches ...Match) []Match {
+		h, err := LoadScheduleHistory(path)
+		require.NoError(t, err)
+		SetScheduleHistory(h)
+		defer SetScheduleHistory(nil)
+
+		teamA.Matches = matches
+		ObserveSchedule(teamA)
+		_, future := org.Matches(0, 14*24*time.Hour, boundary)
+		require.NoError(t, h.Save())
+		return future
+	}
+
+	// First run: nothing to compare against.
+	future := run(match(1, 14, 18), match(2, 16, 18))
+	require.True(t, future[0].RescheduledFrom.IsZero())
+	require.True(t, future[1].RescheduledFrom.IsZero())
+
+	// Match 1 moves from Tue 4/14 to Fri 4/17.
+	future = run(match(1, 17, 18), match(2, 16, 18))
+	require.Equal(t, 2, future[0].Number)
+	require.True(t, future[0].RescheduledFrom.IsZero())
+	require.Equal(t, 1, future[1].Number)
+	require.Equal(t, time.Date(2026, 4, 14, 18, 0, 0, 0, tz), future[1].RescheduledFrom.In(tz))
+
+	// Re-running without further changes keeps the note.
+	future = run(match(1, 17, 18), match(2, 16, 18))
+	require.Equal(t, time.Date(2026, 4, 14, 18, 0, 0, 0, tz), future[1].RescheduledFrom.In(tz))
+
+	// A playoff has no number, so it's known by its opponent and type.
+	playoff := match(0, 20, 18)
+	playoff.Type = MatchTypePlayoff
+	future = run(playoff)
+	require.True(t, future[0].RescheduledFrom.IsZero())
+
+	playoff.Date = time.Date(2026, 4, 22, 18, 0, 0, 0, tz)
+	future = run(playoff)
+	require.Equal(t, time.Date(2026, 4, 20, 18, 0, 0, 0, tz), future[0].RescheduledFrom.In(tz))
+}
+
+func TestObserveScheduleClearsPlayedMatches(t *testing.T) {
+	h, err := LoadScheduleHistory(filepath.Join(t.TempDir(), "schedule.json"))
+	require.NoError(t, err)
+	h.previous["1/1"] = scheduleRecord{
+		Date:            time.Date(2026, 4, 17, 18, 0, 0, 0, tz),
+		HasTime:         true,
+		RescheduledFrom: time.Date(2026, 4, 14, 18, 0, 0, 0, tz),
+	}
+
+	team := &Team{ID: 1}
+	m := Match{
+		Number:       1,
+		Date:         time.Date(2026, 4, 17, 18, 0, 0, 0, tz),
+		HasTime:      true,
+		HomeTeam:     team,
+		VisitingTeam: &Team{ID: 2},
+	}
+	require.False(t, h.observe(team.ID, m).IsZero())
+
+	// Once played, the move is no longer news.
+	m.Outcome = Outcome{Kind: OutcomePlayed, WinningTeam: team, WinnerPoints: 3, LoserPoints: 2}
+	require.True(t, h.observe(team.ID, m).IsZero())
+	require.True(t, h.current["1/1"].RescheduledFrom.IsZero())
+}
+
+func TestOrganizationMatchesLeavesHistoryAlone(t *testing.T) {
+	h, err := LoadScheduleHistory(filepath.Join(t.TempDir(), "schedule.json"))
+	require.NoError(t, err)
+	SetScheduleHistory(h)
+	defer SetScheduleHistory(nil)
+
+	team := &Team{ID: 1}
+	team.Matches = []Match{{
+		Number:       1,
+		Date:         time.Date(2026, 4, 14, 18, 0, 0, 0, tz),
+		HasTime:      true,
+		HomeTeam:     team,
+		VisitingTeam: &Team{ID: 2},
+	}}
+	org := &Organization{Teams: []*Team{team}}
+	org.Matches(0, 14*24*time.Hour, time.Date(2026, 4, 13, 0, 0, 0, 0, tz))
+	org.Matches(0, 14*24*time.Hour, time.Date(2026, 4, 13, 0, 0, 0, 0, tz))
+	require.Empty(t, h.current)
+}
+
+func TestScheduleHistoryIgnoresGainingATime(t *testing.T) {
+	h, err := LoadScheduleHistory(filepath.Join(t.TempDir(), "schedule.json"))
+	require.NoError(t, err)
+
+	m := Match{Number: 3, Date: time.Date(2026, 4, 14, 0, 0, 0, 0, tz)}
+	require.True(t, h.observe(1, m).IsZero())
+	require.NoError(t, h.Save())
+
+	h, err = LoadScheduleHistory(h.Path())
+	require.NoError(t, err)
+	m.Date = time.Date(2026, 4, 14, 18, 30, 0, 0, tz)
+	m.HasTime = true
+	require.True(t, h.observe(1, m).IsZero())
+}
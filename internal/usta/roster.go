@@ -0,0 +1,178 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ratingRegex splits a combined rating cell such as "3.5C" or "4.0 S" into
+// the NTRP level and rating type.
+var ratingRegex = regexp.MustCompile(`^(\d\.\d)\s*([A-Za-z]?)$`)
+
+// rosterMu guards the players and captain that LoadRoster sets on a team,
+// which may be shared by concurrent loads.
+var rosterMu sync.Mutex
+
+// LoadRoster loads the players on a team's roster.
+func (t *Team) LoadRoster(ctx context.Context) ([]Player, error) {
+	rosterMu.Lock()
+	if t.Players != nil {
+		defer rosterMu.Unlock()
+		return t.Players, nil
+	}
+	rosterMu.Unlock()
+
+	cacheKey := fmt.Sprintf("roster:%d", t.ID)
+
+	// Use singleflight to deduplicate concurrent requests
+	result, err, _ := rosterGroup.Do(cacheKey, func() (interface{}, error) {
+		if cached, ok := rosterCache.get(cacheKey); ok {
+			slog.Debug("roster cache hit", "team_id", t.ID)
+			return cached.([]Player), nil
+		}
+
+		doc := t.doc
+		if doc == nil {
+			u := fmt.Sprintf(teamURL, t.ID)
+			slog.Debug("fetching team page for roster", "team_id", t.ID, "url", u)
+			var err error
+			doc, err = fetchDocument(ctx, PageTeam, u)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		players := parseRoster(doc)
+		rosterCache.set(cacheKey, players)
+
+		return players, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	rosterMu.Lock()
+	defer rosterMu.Unlock()
+	if t.Players == nil {
+		t.Players = result.([]Player)
+		t.captainFromRoster()
+	}
+	return t.Players, nil
+}
+
+// rosterColumns holds the column index of each roster field, or -1 if the
+// roster table has no such column.
+type rosterColumns struct {
+	name, ustaNumber, rating, ratingType, role int
+}
+
+// parseRoster extracts players from the roster table on a team page. The
+// table is found by its header row, which must have "Name" and "Rating"
+// columns.
+func parseRoster(doc *goquery.Document) []Player {
+	players := []Player{}
+	var cols *rosterColumns
+	var header *goquery.Selection
+
+	doc.Find("tr").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		if cols = rosterHeader(row.ChildrenFiltered("td,th")); cols != nil {
+			header = row
+			return false
+		}
+		return true
+	})
+	if cols == nil {
+		return players
+	}
+
+	// Only the rows of the roster table itself, after its header, are
+	// players; later tables on the page are something else.
+	table := header.Closest("table")
+	started := false
+	table.Find("tr").Each(func(i int, row *goquery.Selection) {
+		if !started {
+			started = row.IsSelection(header)
+			return
+		}
+		if !row.Closest("table").IsSelection(table) {
+			return
+		}
+		cells := row.ChildrenFiltered("td,th")
+
+		if cells.Length() <= cols.name {
+			return
+		}
+
+		nameCell := cells.Eq(cols.name)
+		link := nameCell.Find("a[href*='playermatches.asp']").First()
+		if link.Length() == 0 {
+			return
+		}
+
+		p := parsePlayerName(strings.TrimSpace(link.Text()))
+		href, _ := link.Attr("href")
+		p.ID, _ = parseIDParam(href)
+
+		if cols.ustaNumber >= 0 && cols.ustaNumber < cells.Length() {
+			p.USTANumber, _ = strconv.Atoi(cellText(cells.Eq(cols.ustaNumber)))
+		}
+		if cols.rating >= 0 && cols.rating < cells.Length() {
+			rating := cellText(cells.Eq(cols.rating))
+			if m := ratingRegex.FindStringSubmatch(rating); m != nil {
+				p.Rating, p.RatingType = m[1], strings.ToUpper(m[2])
+			} else {
+				p.Rating = rating
+			}
+		}
+		if cols.ratingType >= 0 && cols.ratingType < cells.Length() {
+			if rt := cellText(cells.Eq(cols.ratingType)); rt != "" {
+				p.RatingType = strings.ToUpper(rt)
+			}
+		}
+
+		role := cellText(nameCell)
+		if cols.role >= 0 && cols.role < cells.Length() {
+			role += " " + cellText(cells.Eq(cols.role))
+		}
+		p.Captain = strings.Contains(strings.ToLower(role), "captain") || strings.Contains(role, "(C)")
+
+		players = append(players, p)
+	})
+
+	return players
+}
+
+// rosterHeader returns the roster column positions if cells is the roster
+// table's header row, or nil otherwise.
+func rosterHeader(cells *goquery.Selection) *rosterColumns {
+	cols := &rosterColumns{name: -1, ustaNumber: -1, rating: -1, ratingType: -1, role: -1}
+
+	cells.Each(func(i int, cell *goquery.Selection) {
+		h := strings.ToLower(cellText(cell))
+		switch {
+		case h == "name" || h == "player" || h == "player name":
+			cols.name = i
+		case strings.HasPrefix(h, "usta"):
+			cols.ustaNumber = i
+		case h == "rating type" || h == "type":
+			cols.ratingType = i
+		case strings.Contains(h, "rating") || h == "ntrp":
+			cols.rating = i
+		case h == "role" || strings.Contains(h, "captain"):
+			cols.role = i
+		}
+	})
+
+	if cols.name < 0 || cols.rating < 0 {
+		return nil
+	}
+	return cols
+}
@@ -0,0 +1,80 @@
+package usta
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
+)
+
+const testRosterHTML = `<html><body>
+<table>
+  <tr><td><b>Name</b></td><td><b>City</b></td><td><b>USTA #</b></td><td><b>Rating</b></td><td><b>Role</b></td></tr>
+  <tr>
+    <td><a href="playermatches.asp?id=101">Smith, Jane</a></td>
+    <td>San Jose</td>
+    <td>2012345678</td>
+    <td>3.5C</td>
+    <td>Captain</td>
+  </tr>
+  <tr>
+    <td><a href="playermatches.asp?id=102">Lee, Amy</a></td>
+    <td>Campbell</td>
+    <td></td>
+    <td>3.0 S</td>
+    <td></td>
+  </tr>
+  <tr><td colspan="5">2 players</td></tr>
+</table>
+<table>
+  <tr><td><b>Opponent</b></td><td><b>Date</b></td><td><b>Score</b></td></tr>
+  <tr>
+    <td><a href="playermatches.asp?id=901">Opponent, Olga</a></td>
+    <td>04/14/26</td>
+    <td>6-4 6-3</td>
+  </tr>
+</table>
+</body></html>`
+
+func TestParseRoster(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testRosterHTML))
+	require.NoError(t, err)
+
+	players := parseRoster(doc)
+	require.Equal(t, []Player{
+		{ID: 101, USTANumber: 2012345678, FirstName: "Jane", LastName: "Smith", Rating: "3.5", RatingType: "C", Captain: true},
+		{ID: 102, FirstName: "Amy", LastName: "Lee", Rating: "3.0", RatingType: "S"},
+	}, players)
+}
+
+func TestLoadRosterConcurrently(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testRosterHTML))
+	require.NoError(t, err)
+
+	// The same team may be shared, or loaded more than once.
+	team := &Team{ID: 515151, doc: doc}
+	other := &Team{ID: 515151, doc: doc}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tm := team
+			if i%2 == 1 {
+				tm = other
+			}
+			players, err := tm.LoadRoster(context.Background())
+			require.NoError(t, err)
+			require.Len(t, players, 2)
+		}()
+	}
+	wg.Wait()
+
+	for _, tm := range []*Team{team, other} {
+		require.Len(t, tm.Players, 2)
+		require.Equal(t, "Jane Smith", tm.CaptainName)
+	}
+}
@@ -0,0 +1,143 @@
+package usta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StartTime is when a group of a match's lines starts.
+type StartTime struct {
+	Hour   int
+	Minute int
+	// Lines is the number of lines starting at this time, or 0 if the
+	// schedule doesn't say.
+	Lines int
+}
+
+// Schedule is the structured form of a match's time cell, e.g.
+// "3/1 at 6:30 PM and 7:45 PM Gate Code 24865" or
+// "All 3 at 2:00 PM Courts 7, 8 and 9".
+type Schedule struct {
+	Starts []StartTime
+	Courts []string
+	// GateCode is the code for the facility gate, if given. It should not
+	// be shown unless the user asks for it.
+	GateCode string
+	// Note is whatever text in the time cell isn't a start time, court or
+	// gate code, e.g. "backup(Sundays ) if raining".
+	Note string
+}
+
+// Staggered reports whether the match's lines start at different times.
+func (s Schedule) Staggered() bool {
+	return len(s.Starts) > 1
+}
+
+// LineStarts returns the number of the first line starting at each start
+// time, or nil if the schedule doesn't say how many lines start when.
+func (s Schedule) LineStarts() []int {
+	first := make([]int, len(s.Starts))
+	next := 1
+	for i, st := range s.Starts {
+		if st.Lines == 0 {
+			return nil
+		}
+		first[i] = next
+		next += st.Lines
+	}
+	return first
+}
+
+const clockPattern = `\d{1,2}:\d\d\s*[aApP]\.?[mM]\.?`
+
+var (
+	// clockRegex matches a single 12-hour clock time, e.g. "6:30 PM".
+	clockRegex = regexp.MustCompile(`(\d{1,2}):(\d\d)\s*([aApP])\.?[mM]\.?`)
+	// startGroupRegex matches a group of lines starting at one or more
+	// times, e.g. "All 3 at 7:30 PM", "2 at 6:30 PM" or
+	// "at 6:30 PM and 7:45 PM".
+	startGroupRegex = regexp.MustCompile(`(?i)(?:(?:^|[\s,])(?:(?:and|&)\s+)?(?:all\s+)?(\d)\s+)?\bat\s+(` + clockPattern + `(?:\s*(?:,|&|and)\s*` + clockPattern + `)*)`)
+	// courtsRegex matches court numbers, e.g. "Courts 7, 8 and 9" or
+	// "CTS 3,4,5".
+	courtsRegex = regexp.MustCompile(`(?i)\b(?:courts?|cts?)\.?\s*#?\s*(\d+(?:\s*(?:,|&|and)\s*\d+)*)`)
+	// gateCodeRegex matches a gate code, e.g. "Gate Code 24865" or
+	// "gate code: #1234".
+	gateCodeRegex = regexp.MustCompile(`(?i)\bgate\s*code\s*(?:is\s+|:\s*)?#?\s*([0-9A-Za-z*#]+)`)
+	// leadingDateRegex matches a date leading the time cell, e.g. "3/1".
+	leadingDateRegex = regexp.MustCompile(`^\d{1,2}/\d{1,2}(?:/\d{2,4})?\b`)
+	digitsRegex      = regexp.MustCompile(`\d+`)
+)
+
+// parseSchedule parses the time cell of a team's schedule. Only the first
+// start group needs no line count; later ones must say how many lines start
+// then, so that times like "Warm up court available at 10:30am" end up in the
+// note instead.
+func parseSchedule(u string) Schedule {
+	var s Schedule
+	u = strings.Join(strings.Fields(u), " ")
+	if u == "" {
+		return s
+	}
+
+	// Sectionals use a bare time for the whole match.
+	if bareTimeRegex.MatchString(u) || bareAMPMTimeRegex.MatchString(u) {
+		hour, minute, err := parseTime(u)
+		if err == nil {
+			s.Starts = []StartTime{{Hour: hour, Minute: minute}}
+		}
+		return s
+	}
+
+	rest := u
+	if m := gateCodeRegex.FindStringSubmatch(rest); m != nil {
+		s.GateCode = m[1]
+		rest = strings.Replace(rest, m[0], " ", 1)
+	}
+
+	if m := courtsRegex.FindStringSubmatch(rest); m != nil {
+		s.Courts = digitsRegex.FindAllString(m[1], -1)
+		rest = strings.Replace(rest, m[0], " ", 1)
+	}
+
+	for i, m := range startGroupRegex.FindAllStringSubmatch(rest, -1) {
+		if i > 0 && m[1] == "" {
+			continue
+		}
+		lines, _ := strconv.Atoi(m[1])
+		times := clockRegex.FindAllStringSubmatch(m[2], -1)
+		for _, t := range times {
+			hour, _ := strconv.Atoi(t[1])
+			minute, _ := strconv.Atoi(t[2])
+			hour = to24Hour(hour, t[3])
+			st := StartTime{Hour: hour, Minute: minute}
+			// A line count only applies when it goes with a single time.
+			if len(times) == 1 {
+				st.Lines = lines
+			}
+			s.Starts = append(s.Starts, st)
+		}
+		rest = strings.Replace(rest, m[0], " ", 1)
+	}
+
+	rest = leadingDateRegex.ReplaceAllString(strings.TrimSpace(rest), "")
+	s.Note = strings.Trim(strings.Join(strings.Fields(rest), " "), " ,;-")
+
+	return s
+}
+
+// to24Hour converts a 12-hour clock hour to a 24-hour one. ampm is "a" or
+// "p" in either case, optionally followed by "m".
+func to24Hour(hour int, ampm string) int {
+	switch strings.ToLower(ampm)[0] {
+	case 'p':
+		if hour < 12 {
+			hour += 12
+		}
+	case 'a':
+		if hour == 12 {
+			hour = 0
+		}
+	}
+	return hour
+}
@@ -0,0 +1,61 @@
+package usta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := map[string]Schedule{
+		"All 3 at 7:30 PM": {
+			Starts: []StartTime{{19, 30, 3}},
+		},
+		"3/1 at 6:30 PM and 7:45 PM Gate Code 24865": {
+			Starts:   []StartTime{{18, 30, 0}, {19, 45, 0}},
+			GateCode: "24865",
+		},
+		"All 3 at 2:00 PM Courts 7, 8 and 9": {
+			Starts: []StartTime{{14, 0, 3}},
+			Courts: []string{"7", "8", "9"},
+		},
+		"All 3 at 12:00 PM CTS 3,4,5": {
+			Starts: []StartTime{{12, 0, 3}},
+			Courts: []string{"3", "4", "5"},
+		},
+		"All 3 at 9:30 AM backup(Sundays ) if raining": {
+			Starts: []StartTime{{9, 30, 3}},
+			Note:   "backup(Sundays ) if raining",
+		},
+		"All 3 at 11:00 AM Warm up court available at 10:30am.": {
+			Starts: []StartTime{{11, 0, 3}},
+			Note:   "Warm up court available at 10:30am.",
+		},
+		"2 at 6:00 PM and 1 at 7:30 PM": {
+			Starts: []StartTime{{18, 0, 2}, {19, 30, 1}},
+		},
+		"08:00:00": {
+			Starts: []StartTime{{8, 0, 0}},
+		},
+		"8:00pm": {
+			Starts: []StartTime{{20, 0, 0}},
+		},
+		"": {},
+	}
+
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			require.Equal(t, expected, parseSchedule(input))
+		})
+	}
+}
+
+func TestScheduleLineStarts(t *testing.T) {
+	s := parseSchedule("2 at 6:00 PM and 1 at 7:30 PM")
+	require.True(t, s.Staggered())
+	require.Equal(t, []int{1, 3}, s.LineStarts())
+
+	s = parseSchedule("3/1 at 6:30 PM and 7:45 PM")
+	require.True(t, s.Staggered())
+	require.Nil(t, s.LineStarts())
+}
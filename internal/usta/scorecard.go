@@ -0,0 +1,218 @@
+package usta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	scorecardURL = "https://leagues.ustanorcal.com/scorecard.asp?id=%d"
+)
+
+var (
+	// lineLabelRegex matches the first cell of a scorecard line row, e.g.
+	// "Singles 1", "Doubles #2" or "D3".
+	lineLabelRegex = regexp.MustCompile(`(?i)^(singles|doubles|s|d)\s*#?\s*(\d+)$`)
+	// setScoreRegex matches a single set score such as "6-3" or "1-0".
+	setScoreRegex = regexp.MustCompile(`(\d+)\s*-\s*(\d+)`)
+	// parentheticalRegex matches tiebreak details such as "(7)" or "(10-8)"
+	// that follow a set score.
+	parentheticalRegex = regexp.MustCompile(`\([^)]*\)`)
+)
+
+// LoadScorecard loads the lines of the scorecard with the given ID. The
+// returned lines have Winner set but not WinningTeam; use Match.LoadLines to
+// attach them to a match.
+func LoadScorecard(ctx context.Context, id int) ([]Line, error) {
+	cacheKey := fmt.Sprintf("scorecard:%d", id)
+
+	// Use singleflight to deduplicate concurrent requests
+	result, err, _ := scorecardGroup.Do(cacheKey, func() (interface{}, error) {
+		if cached, ok := scorecardCache.get(cacheKey); ok {
+			slog.Debug("scorecard cache hit", "scorecard_id", id)
+			return cached.([]Line), nil
+		}
+
+		u := fmt.Sprintf(scorecardURL, id)
+		slog.Debug("fetching scorecard page", "scorecard_id", id, "url", u)
+		doc, err := fetchDocument(ctx, PageScorecard, u)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := parseScorecard(doc)
+		scorecardCache.set(cacheKey, lines)
+
+		return lines, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]Line), nil
+}
+
+// parseScorecard extracts the lines from a scorecard page. Each line row
+// starts with a label cell ("Singles 1", "Doubles 2", …) followed by the home
+// players, the visiting players, the score and, optionally, the winning side.
+func parseScorecard(doc *goquery.Document) []Line {
+	var lines []Line
+
+	doc.Find("tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.ChildrenFiltered("td")
+		if cells.Length() < 4 {
+			return
+		}
+
+		m := lineLabelRegex.FindStringSubmatch(cellText(cells.Eq(0)))
+		if m == nil {
+			return
+		}
+
+		l := Line{Kind: LineDoubles}
+		if strings.HasPrefix(strings.ToLower(m[1]), "s") {
+			l.Kind = LineSingles
+		}
+		l.Number, _ = strconv.Atoi(m[2])
+
+		home := parsePlayers(cells.Eq(1))
+		away := parsePlayers(cells.Eq(2))
+		if len(home) > 0 {
+			l.HomePlayer1 = home[0]
+		}
+		if len(home) > 1 {
+			l.HomePlayer2 = home[1]
+		}
+		if len(away) > 0 {
+			l.AwayPlayer1 = away[0]
+		}
+		if len(away) > 1 {
+			l.AwayPlayer2 = away[1]
+		}
+
+		l.WinnerScore = cellText(cells.Eq(3))
+		l.Sets = parseSetScores(l.WinnerScore)
+
+		if cells.Length() > 4 {
+			l.Winner = parseSide(cellText(cells.Eq(4)))
+		}
+		if l.Winner == SideUnknown {
+			l.Winner = winnerFromSets(l.Sets)
+		}
+
+		lines = append(lines, l)
+	})
+
+	return lines
+}
+
+// parsePlayers returns the players listed in a scorecard cell. Players are
+// normally links to their match history; plain text separated by "/" or "&"
+// is accepted as a fallback.
+func parsePlayers(cell *goquery.Selection) []Player {
+	var players []Player
+
+	cell.Find("a").Each(func(i int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		if !strings.Contains(href, "playermatches.asp") {
+			return
+		}
+		p := parsePlayerName(a.Text())
+		p.ID, _ = parseIDParam(href)
+		players = append(players, p)
+	})
+	if len(players) > 0 {
+		return players
+	}
+
+	text := cellText(cell)
+	if text == "" {
+		return nil
+	}
+	for _, name := range strings.FieldsFunc(text, func(r rune) bool { return r == '/' || r == '&' }) {
+		if p := parsePlayerName(name); p.LastName != "" {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// parsePlayerName parses "Last, First" or "First Last" into a Player.
+func parsePlayerName(name string) Player {
+	name = strings.Join(strings.Fields(name), " ")
+	if last, first, ok := strings.Cut(name, ","); ok {
+		return Player{FirstName: strings.TrimSpace(first), LastName: strings.TrimSpace(last)}
+	}
+
+	idx := strings.LastIndex(name, " ")
+	if idx < 0 {
+		return Player{LastName: name}
+	}
+	return Player{FirstName: name[:idx], LastName: name[idx+1:]}
+}
+
+// parseSetScores parses a score string such as "6-3, 4-6, 1-0(10-7)" into
+// per-set scores. Tiebreak details in parentheses are ignored.
+func parseSetScores(score string) []SetScore {
+	score = parentheticalRegex.ReplaceAllString(score, "")
+
+	var sets []SetScore
+	for _, m := range setScoreRegex.FindAllStringSubmatch(score, -1) {
+		home, _ := strconv.Atoi(m[1])
+		visiting, _ := strconv.Atoi(m[2])
+		sets = append(sets, SetScore{Home: home, Visiting: visiting})
+	}
+	return sets
+}
+
+func parseSide(s string) Side {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "home", "h":
+		return SideHome
+	case "visitor", "visiting", "away", "v":
+		return SideVisiting
+	default:
+		return SideUnknown
+	}
+}
+
+func winnerFromSets(sets []SetScore) Side {
+	var home, visiting int
+	for _, s := range sets {
+		if s.Home > s.Visiting {
+			home++
+		} else if s.Visiting > s.Home {
+			visiting++
+		}
+	}
+
+	switch {
+	case home > visiting:
+		return SideHome
+	case visiting > home:
+		return SideVisiting
+	default:
+		return SideUnknown
+	}
+}
+
+// parseIDParam returns the integer "id" query parameter of u.
+func parseIDParam(u string) (int, error) {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(pu.Query().Get("id"))
+}
+
+func cellText(sel *goquery.Selection) string {
+	return strings.Join(strings.Fields(sel.Text()), " ")
+}
@@ -0,0 +1,72 @@
+package usta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
+)
+
+const testScorecardHTML = `<html><body>
+<table>
+  <tr><td>Line</td><td>Home Team</td><td>Visiting Team</td><td>Score</td><td>Winner</td></tr>
+  <tr>
+    <td>Singles 1</td>
+    <td><a href="playermatches.asp?id=101">Smith, Jane</a></td>
+    <td><a href="playermatches.asp?id=201">Doe, Ann</a></td>
+    <td>6-3, 6-4</td>
+    <td>Home</td>
+  </tr>
+  <tr>
+    <td>Doubles 1</td>
+    <td><a href="playermatches.asp?id=102">Lee, Amy</a><br><a href="playermatches.asp?id=103">Wong, Beth</a></td>
+    <td><a href="playermatches.asp?id=202">Park, Cara</a><br><a href="playermatches.asp?id=203">Chen, Dana</a></td>
+    <td>4-6, 7-6(5), 0-1(8-10)</td>
+    <td></td>
+  </tr>
+</table>
+</body></html>`
+
+func TestParseScorecard(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testScorecardHTML))
+	require.NoError(t, err)
+
+	lines := parseScorecard(doc)
+	require.Len(t, lines, 2)
+
+	s1 := lines[0]
+	require.Equal(t, 1, s1.Number)
+	require.Equal(t, LineSingles, s1.Kind)
+	require.Equal(t, Player{ID: 101, FirstName: "Jane", LastName: "Smith"}, s1.HomePlayer1)
+	require.Equal(t, Player{ID: 201, FirstName: "Ann", LastName: "Doe"}, s1.AwayPlayer1)
+	require.Len(t, s1.HomePlayers(), 1)
+	require.Equal(t, "6-3, 6-4", s1.WinnerScore)
+	require.Equal(t, []SetScore{{6, 3}, {6, 4}}, s1.Sets)
+	require.Equal(t, SideHome, s1.Winner)
+
+	d1 := lines[1]
+	require.Equal(t, 1, d1.Number)
+	require.Equal(t, LineDoubles, d1.Kind)
+	require.Equal(t, "Wong", d1.HomePlayer2.LastName)
+	require.Equal(t, 203, d1.AwayPlayer2.ID)
+	require.Len(t, d1.AwayPlayers(), 2)
+	require.Equal(t, []SetScore{{4, 6}, {7, 6}, {0, 1}}, d1.Sets)
+	require.Equal(t, SideVisiting, d1.Winner)
+}
+
+func TestParsePlayerName(t *testing.T) {
+	cases := map[string]Player{
+		"Smith, Jane":      {FirstName: "Jane", LastName: "Smith"},
+		"Jane Smith":       {FirstName: "Jane", LastName: "Smith"},
+		"Mary Ann  Smith":  {FirstName: "Mary Ann", LastName: "Smith"},
+		"Smith":            {LastName: "Smith"},
+		" de la Cruz, Ana": {FirstName: "Ana", LastName: "de la Cruz"},
+	}
+
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			require.Equal(t, expected, parsePlayerName(input))
+		})
+	}
+}
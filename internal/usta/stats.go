@@ -0,0 +1,63 @@
+package usta
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// FetchStats counts the pages loaders get, for reporting progress. It is
+// safe for concurrent use.
+type FetchStats struct {
+	fetched   atomic.Int64
+	cacheHits atomic.Int64
+	onChange  func()
+}
+
+// NewFetchStats returns empty FetchStats. onChange, if not nil, is called
+// after each page is counted.
+func NewFetchStats(onChange func()) *FetchStats {
+	return &FetchStats{onChange: onChange}
+}
+
+// Fetched returns the number of pages requested from the USTA site,
+// including stale cached pages revalidated with it.
+func (s *FetchStats) Fetched() int {
+	return int(s.fetched.Load())
+}
+
+// CacheHits returns the number of pages served from the on-disk cache or a
+// replay archive without a request.
+func (s *FetchStats) CacheHits() int {
+	return int(s.cacheHits.Load())
+}
+
+func (s *FetchStats) count(n *atomic.Int64) {
+	n.Add(1)
+	if s.onChange != nil {
+		s.onChange()
+	}
+}
+
+type fetchStatsKey struct{}
+
+// WithFetchStats returns a context whose loaders count the pages they get
+// in s.
+func WithFetchStats(ctx context.Context, s *FetchStats) context.Context {
+	return context.WithValue(ctx, fetchStatsKey{}, s)
+}
+
+// countFetched counts a page requested from the USTA site in the context's
+// FetchStats, if any.
+func countFetched(ctx context.Context) {
+	if s, _ := ctx.Value(fetchStatsKey{}).(*FetchStats); s != nil {
+		s.count(&s.fetched)
+	}
+}
+
+// countCacheHit counts a page served without a request in the context's
+// FetchStats, if any.
+func countCacheHit(ctx context.Context) {
+	if s, _ := ctx.Value(fetchStatsKey{}).(*FetchStats); s != nil {
+		s.count(&s.cacheHits)
+	}
+}
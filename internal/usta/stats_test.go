@@ -0,0 +1,34 @@
+package usta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchStatsCountsPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>team</html>"))
+	}))
+	defer srv.Close()
+
+	SetDiskCache(NewDiskCache(t.TempDir(), map[PageKind]time.Duration{PageTeam: time.Hour}))
+	defer SetDiskCache(nil)
+
+	changes := 0
+	stats := NewFetchStats(func() { changes++ })
+	ctx := WithFetchStats(context.Background(), stats)
+
+	_, err := fetchPage(ctx, PageTeam, srv.URL)
+	require.NoError(t, err)
+	_, err = fetchPage(ctx, PageTeam, srv.URL)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, stats.Fetched())
+	require.Equal(t, 1, stats.CacheHits())
+	require.Equal(t, 2, changes)
+}
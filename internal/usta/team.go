@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -20,6 +20,7 @@ const (
 )
 
 var (
+	// tz is the league time zone match dates and times are read in.
 	tz, _ = time.LoadLocation("America/Los_Angeles")
 	// timeRegex matches the usual "All 3 at 7:30 PM ..." format used for
 	// regular-season and playoff matches.
@@ -31,8 +32,33 @@ var (
 	// other Sectional matches, which omit the "at" prefix and any space
 	// before the AM/PM marker.
 	bareAMPMTimeRegex = regexp.MustCompile(`^(\d{1,2}):(\d\d)\s*([aApP][mM])$`)
+	// outcomePointsRegex matches the points in an outcome such as "Won 3-2".
+	outcomePointsRegex = regexp.MustCompile(`(\d+)-(\d+)`)
+	// wholeMatchDefaultRegex matches an outcome saying the whole match was
+	// defaulted or forfeited, e.g. "Won by Default" or "Lost 0-5 (Forfeit)",
+	// but not one saying only a line was, e.g. "Won 4-1 Def. line 5".
+	wholeMatchDefaultRegex = regexp.MustCompile(`\bby (default|forfeit)\b|\((default|forfeit|def\.?)\)$`)
+	// wholeMatchRetiredRegex matches an outcome saying the match ended with
+	// a retirement, e.g. "Won 3-2 (Retired)", but not one saying only a line
+	// did, e.g. "Won 3-2 ret. line 2".
+	wholeMatchRetiredRegex = regexp.MustCompile(`\((retired|ret\.?)\)$`)
+	// rainoutRegex and postponedRegex match an outcome cell that says only
+	// that the match wasn't played, e.g. "Rained Out" or "Postponed".
+	rainoutRegex   = regexp.MustCompile(`^rain(ed)? ?out\b`)
+	postponedRegex = regexp.MustCompile(`^(postponed|ppd)\b`)
 )
 
+// SetTimeZone sets the league time zone match dates and times are read in,
+// and matches are windowed in. It defaults to America/Los_Angeles.
+func SetTimeZone(loc *time.Location) {
+	tz = loc
+}
+
+// TimeZone returns the league time zone.
+func TimeZone() *time.Location {
+	return tz
+}
+
 // Team represents a USTA NorCal team.
 type Team struct {
 	ID           int           `json:"id"`
@@ -40,8 +66,19 @@ type Team struct {
 	Name         string        `json:"name"`
 	Code         string        `json:"code,omitempty"`
 	Matches      []Match       `json:"matches"`
+	Players      []Player      `json:"players,omitempty"`
+	Flight       *Flight       `json:"flight,omitempty"`
 	Extra        bool          `json:"extra,omitempty"`
 
+	League         League `json:"league,omitempty"`
+	AgeDivision    string `json:"age_division,omitempty"` // e.g. "18+", "55+"
+	Area           string `json:"area,omitempty"`
+	FlightID       int    `json:"flight_id,omitempty"`
+	FlightName     string `json:"flight_name,omitempty"`
+	Season         int    `json:"season,omitempty"`
+	CaptainName    string `json:"captain_name,omitempty"`
+	CaptainContact string `json:"captain_contact,omitempty"`
+
 	doc *goquery.Document
 }
 
@@ -58,22 +95,9 @@ func LoadTeam(ctx context.Context, id int) (*Team, error) {
 
 		u := fmt.Sprintf(teamURL, id)
 		slog.Debug("fetching team page", "team_id", id, "url", u)
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not create team request")
-		}
-		res, err := httpClient.Do(req)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not fetch team page")
-		}
-		defer res.Body.Close()
-		if res.StatusCode != 200 {
-			return nil, fmt.Errorf("error fetching team page, code: %d", res.StatusCode)
-		}
-
-		doc, err := goquery.NewDocumentFromReader(res.Body)
+		doc, err := fetchDocument(ctx, PageTeam, u)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not read team page")
+			return nil, err
 		}
 
 		t := new(Team)
@@ -88,6 +112,8 @@ func LoadTeam(ctx context.Context, id int) (*Team, error) {
 		cellText := strings.TrimSpace(nameCell.Text())
 		t.Code = strings.TrimSpace(strings.TrimPrefix(cellText, t.Name))
 
+		t.parseMetadata(doc)
+
 		teamCache.set(cacheKey, t)
 
 		return t, nil
@@ -105,6 +131,9 @@ func (t *Team) LoadOrganization(ctx context.Context) (*Team, error) {
 	if t.Organization != nil {
 		return t, nil
 	}
+	if t.doc == nil {
+		return nil, fmt.Errorf("organization unknown for team ID = %d", t.ID)
+	}
 
 	var orgID int
 
@@ -151,94 +180,43 @@ func (t *Team) LoadMatches(ctx context.Context) (*Team, error) {
 	}
 	slog.Debug("parsing matches", "team_id", t.ID, "team_name", t.Name)
 
-	// First pass: collect all match data and opposing team IDs
-	type matchData struct {
-		matchNumber  int
-		date         time.Time
-		hasTime      bool
-		teamID       int
-		location     string
-		outcomeVerb  string
-		winnerPoints int
-		loserPoints  int
+	// First pass: parse the schedule table and collect opposing team IDs
+	rows, err := t.ScheduleRows()
+	if errors.Is(err, ErrNoScheduleTable) {
+		// Most likely the page layout changed, which would otherwise leave
+		// every team silently without matches.
+		ReportFailure(ctx, LoadFailure{
+			What:   "schedule table",
+			ID:     t.ID,
+			TeamID: t.ID,
+			URL:    fmt.Sprintf(teamURL, t.ID),
+			Err:    err,
+		})
+		t.Matches = []Match{}
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	var matchDataList []matchData
+	var entries []*ScheduleEntry
 	var opposingTeamIDs []int
-
-	t.doc.Find("table tbody tr td table tbody tr").Each(func(i int, sel *goquery.Selection) {
-		bgcolor, exists := sel.Attr("bgcolor")
-		if !exists {
-			return
-		}
-
-		if bgcolor != "white" && bgcolor != "#D2D2FF" {
-			return
-		}
-
-		cells := sel.Find("td")
-		if cells.Length() < 2 {
-			return
-		}
-
-		// Parse match number from column 1 (e.g., "2 (4/13-4/19) Full RR#1" → 2)
-		matchNumText := strings.TrimSpace(cells.Get(1).FirstChild.Data)
-		matchNum, _ := strconv.Atoi(strings.Fields(matchNumText)[0])
-
-		// Parse match date
-		c := cells.Get(2).FirstChild
-		if c.NextSibling != nil {
-			c = c.NextSibling.FirstChild
-		}
-
-		v := strings.TrimSpace(c.Data)
-		dt, err := time.ParseInLocation("01/02/06", v, tz)
-		if err != nil {
-			return
-		}
-
-		// Parse match times
-		c = cells.Get(4).FirstChild
-
-		v = strings.TrimSpace(c.Data)
-		hour, minute, err := parseTime(v)
-		if err != nil {
-			return
-		}
-		hasTime := hour > 0
-		if hasTime {
-			dt = time.Date(dt.Year(), dt.Month(), dt.Day(), hour, minute, 0, 0, dt.Location())
-		}
-
-		// Parse opposing team ID
-		v = cells.Get(5).FirstChild.Attr[0].Val
-		teamID, err := parseTeamID(v)
-		if err != nil {
-			return
-		}
-
-		// Parse location (home or away)
-		location := sel.Find("td").Get(6).FirstChild.Data
-
-		// Parse outcome
-		v = sel.Find("td").Get(7).FirstChild.Data
-		verb, winnerPoints, loserPoints, err := parseOutcome(v)
-		if err != nil {
-			return
+	for _, row := range rows {
+		if row.Err != nil {
+			if !errors.Is(row.Err, ErrNotMatchRow) {
+				ReportFailure(ctx, LoadFailure{
+					What:   "schedule row",
+					ID:     t.ID,
+					TeamID: t.ID,
+					URL:    fmt.Sprintf(teamURL, t.ID),
+					Err:    row.Err,
+				})
+			}
+			continue
 		}
-
-		matchDataList = append(matchDataList, matchData{
-			matchNumber:  matchNum,
-			date:         dt,
-			hasTime:      hasTime,
-			teamID:       teamID,
-			location:     location,
-			outcomeVerb:  verb,
-			winnerPoints: winnerPoints,
-			loserPoints:  loserPoints,
-		})
-		opposingTeamIDs = append(opposingTeamIDs, teamID)
-	})
+		entries = append(entries, row.Entry)
+		opposingTeamIDs = append(opposingTeamIDs, row.Entry.OpponentID)
+	}
 
 	// Second pass: load all opposing teams in parallel
 	type teamResult struct {
@@ -261,21 +239,30 @@ func (t *Team) LoadMatches(ctx context.Context) (*Team, error) {
 	for range opposingTeamIDs {
 		result := <-teamChan
 		if result.err != nil {
-			continue // Skip teams that failed to load
+			e := entries[result.idx]
+			ReportFailure(ctx, LoadFailure{
+				What:   "opponent",
+				ID:     e.OpponentID,
+				TeamID: t.ID,
+				URL:    fmt.Sprintf(teamURL, e.OpponentID),
+				Err:    fmt.Errorf("dropped match %d on %s: %w", e.Number, e.Date.Format("2006-01-02"), result.err),
+			})
+			continue
 		}
 		opposingTeams[result.idx] = result.team
 	}
 
-	slog.Debug("loaded opposing teams", "team_id", t.ID, "match_count", len(matchDataList))
+	slog.Debug("loaded opposing teams", "team_id", t.ID, "match_count", len(entries))
 
-	for idx, md := range matchDataList {
+	t.Matches = []Match{}
+	for idx, e := range entries {
 		o := opposingTeams[idx]
 		if o == nil {
-			continue // Skip if team failed to load
+			continue // Already reported above
 		}
 
 		var homeTeam, visitingTeam *Team
-		if md.location == "Home" {
+		if e.Home {
 			homeTeam = t
 			visitingTeam = o
 		} else {
@@ -284,52 +271,82 @@ func (t *Team) LoadMatches(ctx context.Context) (*Team, error) {
 		}
 
 		m := Match{
-			Number:       md.matchNumber,
-			Date:         md.date,
-			HasTime:      md.hasTime,
+			Number:       e.Number,
+			Type:         e.Type,
+			Schedule:     e.Schedule,
+			Date:         e.Date,
+			HasTime:      e.HasTime,
 			HomeTeam:     homeTeam,
 			VisitingTeam: visitingTeam,
+			ScorecardID:  e.ScorecardID,
 		}
 
-		if md.outcomeVerb != "" {
-			var winningTeam *Team
-			if md.outcomeVerb == "Won" {
-				winningTeam = t
+		m.Outcome.Kind = e.OutcomeKind
+		if e.OutcomeVerb != "" {
+			if e.OutcomeVerb == "Won" {
+				m.Outcome.WinningTeam = t
 			} else {
-				winningTeam = o
+				m.Outcome.WinningTeam = o
 			}
-
-			outcome := struct {
-				WinningTeam  *Team
-				WinnerPoints int
-				LoserPoints  int
-			}{
-				WinningTeam:  winningTeam,
-				WinnerPoints: md.winnerPoints,
-				LoserPoints:  md.loserPoints,
-			}
-
-			m.Outcome = outcome
+			m.Outcome.WinnerPoints = e.WinnerPoints
+			m.Outcome.LoserPoints = e.LoserPoints
 		}
 
 		t.Matches = append(t.Matches, m)
 	}
 
+	// Third pass: load per-line results from the scorecards of played matches
+	var wg sync.WaitGroup
+	for i := range t.Matches {
+		if t.Matches[i].ScorecardID == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(m *Match) {
+			defer wg.Done()
+			if err := m.LoadLines(ctx); err != nil {
+				ReportFailure(ctx, LoadFailure{
+					What:   "scorecard",
+					ID:     m.ScorecardID,
+					TeamID: t.ID,
+					URL:    fmt.Sprintf(scorecardURL, m.ScorecardID),
+					Err:    err,
+				})
+			}
+		}(&t.Matches[i])
+	}
+	wg.Wait()
+
 	return t, nil
 }
 
 func (t *Team) ShortName() string {
-	var shortName = t.Name
+	// Strip the season out of short name. If the team's season isn't known,
+	// it's one of the years the team's matches are played in, since a season
+	// can span two calendar years.
+	years := map[string]bool{}
+	if season := t.season(); season != 0 {
+		years[strconv.Itoa(season)] = true
+	} else {
+		for _, m := range t.Matches {
+			years[m.Date.Format("2006")] = true
+		}
+	}
 
-	// Strip current year out of short name
-	year := time.Now().Format("2006")
-	shortName = strings.Replace(shortName, year+" ", "", -1)
+	var words []string
+	for _, w := range strings.Fields(t.Name) {
+		if !years[w] {
+			words = append(words, w)
+		}
+	}
+	shortName := strings.Join(words, " ")
 
 	// Abbreviate "& Over"
 	shortName = strings.Replace(shortName, " & Over", "+", -1)
 
 	return shortName
 }
+
 func parseTime(u string) (int, int, error) {
 	u = strings.TrimSpace(u)
 	if u == "" {
@@ -391,24 +408,60 @@ func parseTeamID(u string) (int, error) {
 	return int(teamID), nil
 }
 
-func parseOutcome(outcome string) (string, int, int, error) {
-	outcome = strings.TrimSpace(outcome)
-	parts := strings.Split(outcome, " ")
-	if len(parts) != 2 {
-		return "", 0, 0, nil
+// parseOutcome parses the outcome cell of a team's schedule, e.g. "Won 3-2",
+// "Lost 0-5 (Default)", "Won by Forfeit" or "Rained Out". The verb is "Won"
+// or "Lost" from the team's perspective, or empty if no team won. Only an
+// outcome of the whole match is classed as such: a match with a defaulted
+// or retired line, or one delayed by rain, was still played.
+func parseOutcome(outcome string) (string, OutcomeKind, int, int, error) {
+	outcome = strings.Join(strings.Fields(outcome), " ")
+	if outcome == "" {
+		return "", OutcomeUnknown, 0, 0, nil
+	}
+	lower := strings.ToLower(outcome)
+
+	var verb string
+	switch {
+	case strings.HasPrefix(lower, "won") || strings.HasPrefix(lower, "win"):
+		verb = "Won"
+	case strings.HasPrefix(lower, "lost") || strings.HasPrefix(lower, "loss"):
+		verb = "Lost"
+	case rainoutRegex.MatchString(lower):
+		return "", OutcomeRainout, 0, 0, nil
+	case postponedRegex.MatchString(lower):
+		return "", OutcomePostponed, 0, 0, nil
+	default:
+		return "", OutcomeUnknown, 0, 0, nil
 	}
 
-	verb := parts[0]
-	points := strings.Split(parts[1], "-")
+	kind := OutcomePlayed
+	wholeMatch := wholeMatchDefaultRegex.FindString(lower)
+	switch {
+	case strings.Contains(wholeMatch, "forfeit"):
+		kind = OutcomeForfeit
+	case wholeMatch != "":
+		kind = OutcomeDefault
+	case wholeMatchRetiredRegex.MatchString(lower):
+		kind = OutcomeRetired
+	}
+
+	points := outcomePointsRegex.FindStringSubmatch(outcome)
+	if points == nil {
+		// e.g. "Won by Default", with no points shown
+		if kind == OutcomePlayed {
+			return "", OutcomeUnknown, 0, 0, nil
+		}
+		return verb, kind, 0, 0, nil
+	}
 
-	points1, err := strconv.ParseInt(points[0], 10, 0)
+	points1, err := strconv.ParseInt(points[1], 10, 0)
 	if err != nil {
-		return "", 0, 0, err
+		return "", OutcomeUnknown, 0, 0, err
 	}
 
-	points2, err := strconv.ParseInt(points[1], 10, 0)
+	points2, err := strconv.ParseInt(points[2], 10, 0)
 	if err != nil {
-		return "", 0, 0, err
+		return "", OutcomeUnknown, 0, 0, err
 	}
 
 	var winnerPoints, loserPoints int64
@@ -420,5 +473,5 @@ func parseOutcome(outcome string) (string, int, int, error) {
 		loserPoints = points1
 	}
 
-	return verb, int(winnerPoints), int(loserPoints), nil
+	return verb, kind, int(winnerPoints), int(loserPoints), nil
 }
@@ -19,15 +19,37 @@ type TeamDisplay struct {
 	Level      string
 	TeamSuffix string
 	Daytime    bool
+
+	Family      League     // the league family, e.g. LeagueCombo
+	AgeDivision string     // e.g. "40+", or "14U" for juniors
+	Nickname    string     // from the bracketed team code, e.g. "Team Bee's"
+	Captain     string     // the captain's last name
+	Style       LabelStyle // how Label names the team
 }
 
-var teamNameRegex = regexp.MustCompile(
-	`(?i)Adult\s+(\d+)(?:\s*\+|\s+&\s+Over)\s+(Womens|Women'?s|Mens|Men'?s|Mixed)\s+(\d+\.?\d*)(\s*\+|\s+&\s+Over)?`,
+var (
+	// genderRegex matches the gender word in a team name.
+	genderRegex = regexp.MustCompile(`(?i)\b(Womens|Women'?s|Ladies|Girls|Mens|Men'?s|Boys|Mixed|Co-?ed)\b`)
+	// levelRegex matches an NTRP level or combined level in a team name,
+	// e.g. "3.5", "2.5 & Over", "2.5+" or, for Combo and Mixed, "7.5".
+	levelRegex = regexp.MustCompile(`\b(\d+\.\d)(\s*\+|\s+&\s+Over)?`)
+	// juniorLevelRegex matches the play level of a junior team.
+	juniorLevelRegex = regexp.MustCompile(`(?i)\b(Advanced Beginner|Beginner|Intermediate|Advanced|Championship|Champ)\b`)
+	// teamCodeRegex matches the age, family, gender and level in a team
+	// code, e.g. "18AW2.5+A-DT" (18+ Adult Womens 2.5+, team A, daytime),
+	// "40MX7.0B" (40+ Mixed 7.0) or "18CM7.5A" (18+ Combo Mens 7.5).
+	teamCodeRegex = regexp.MustCompile(`\b(\d{2})(MX|A|C|T|J|F)(W|M|X)?(\d+\.\d)(\+)?[A-Z]?(?:-DT)?\b`)
 )
 
-var genderFirstRegex = regexp.MustCompile(
-	`(?i)(Womens|Women'?s|Mens|Men'?s|Mixed)\s+\d+(?:\s*\+|\s+&\s+Over)\s+(\d+\.?\d*)(\s*\+|\s+&\s+Over)?`,
-)
+// codeFamilies maps the family letters in a team code to their leagues.
+var codeFamilies = map[string]League{
+	"A":  LeagueAdult,
+	"MX": LeagueMixed,
+	"C":  LeagueCombo,
+	"T":  LeagueTriLevel,
+	"J":  LeagueJunior,
+	"F":  LeagueFlex,
+}
 
 // teamCodeSuffixRegex extracts the trailing team letter (A, B, C…) from a short
 // code like "ALMADEN SR 40MX7.0A" or "ALMADEN SR 18AW2.5+A-DT".
@@ -45,36 +67,101 @@ func extractTeamSuffix(code string) string {
 }
 
 func (t *Team) Display() TeamDisplay {
-	var genderStr, levelStr, suffixStr string
-
-	if m := teamNameRegex.FindStringSubmatch(t.Name); m != nil {
-		genderStr, levelStr, suffixStr = m[2], m[3], m[4]
-	} else if m := genderFirstRegex.FindStringSubmatch(t.Name); m != nil {
-		genderStr, levelStr, suffixStr = m[1], m[2], m[3]
-	} else {
-		return TeamDisplay{}
+	d := TeamDisplay{
+		TeamSuffix:  extractTeamSuffix(t.Code),
+		Daytime:     t.league() == LeagueDaytime || strings.Contains(strings.ToLower(t.Name), "daytime"),
+		Family:      t.league(),
+		AgeDivision: t.ageDivision(),
+		Nickname:    parseNickname(t.Code),
+		Captain:     lastName(t.CaptainName),
+		Style:       namingPolicy.style(t.ID),
 	}
 
-	var gender Gender
-	switch {
-	case len(genderStr) > 0 && (genderStr[0] == 'W' || genderStr[0] == 'w'):
-		gender = GenderWomens
-	case len(genderStr) > 0 && (genderStr[0] == 'M' && (genderStr[1] == 'e' || genderStr[1] == 'E')):
-		gender = GenderMens
+	if m := genderRegex.FindStringSubmatch(t.Name); m != nil {
+		d.Gender = ParseGender(m[1])
+	}
+
+	switch d.Family {
+	case LeagueTriLevel:
+		// Tri-Level teams play at three levels, e.g. "3.0/3.5/4.0".
+		var levels []string
+		for _, m := range levelRegex.FindAllStringSubmatch(t.Name, -1) {
+			levels = append(levels, m[1])
+		}
+		d.Level = strings.Join(levels, "/")
+	case LeagueJunior:
+		if m := juniorLevelRegex.FindStringSubmatch(t.Name); m != nil {
+			d.Level = m[1]
+		}
 	default:
-		gender = GenderMixed
+		if m := levelRegex.FindStringSubmatch(t.Name); m != nil {
+			d.Level = m[1]
+			if m[2] != "" {
+				d.Level += "+"
+			}
+		}
+	}
+
+	// Fill in whatever the name doesn't say from the team code.
+	if m := teamCodeRegex.FindStringSubmatch(t.Code); m != nil {
+		if d.Family == LeagueUnknown {
+			d.Family = codeFamilies[m[2]]
+		}
+		if d.AgeDivision == "" {
+			d.AgeDivision = m[1] + "+"
+		}
+		if d.Gender == GenderUnknown {
+			switch {
+			case m[2] == "MX" || m[3] == "X":
+				d.Gender = GenderMixed
+			case m[3] != "":
+				d.Gender = ParseGender(m[3])
+			}
+		}
+		if d.Level == "" {
+			d.Level = m[4] + m[5]
+		}
 	}
 
-	level := levelStr
-	if suffixStr != "" {
-		level += "+"
+	switch {
+	case d.Gender == GenderUnknown && (d.Family == LeagueMixed || d.Family == LeagueJunior):
+		// Mixed teams don't always say so, and junior teams are co-ed.
+		d.Gender = GenderMixed
+	case d.Level == "" && d.Family == LeagueTriLevel:
+		d.Level = "Tri-Level"
 	}
 
-	return TeamDisplay{
-		Gender:     gender,
-		Level:      level,
-		TeamSuffix: extractTeamSuffix(t.Code),
-		Daytime:    strings.Contains(strings.ToLower(t.Name), "daytime"),
+	return d
+}
+
+// ParseGender returns the gender for a gender word from a team name, or a
+// gender letter from a team code.
+func ParseGender(s string) Gender {
+	switch strings.ToLower(s) {
+	case "womens", "women's", "women", "ladies", "girls", "w":
+		return GenderWomens
+	case "mens", "men's", "men", "boys", "m":
+		return GenderMens
+	case "mixed", "coed", "co-ed", "x":
+		return GenderMixed
+	default:
+		return GenderUnknown
+	}
+}
+
+// Label returns the team's name as decided by the naming policy: its level,
+// age division and level, nickname or captain's last name. Teams without a
+// nickname or captain are labelled by level.
+func (d TeamDisplay) Label() string {
+	switch {
+	case d.Style == LabelAgeLevel && d.AgeDivision != "":
+		return d.AgeDivision + " " + d.Level
+	case d.Style == LabelNickname && d.Nickname != "":
+		return d.Nickname
+	case d.Style == LabelCaptain && d.Captain != "":
+		return d.Captain
+	default:
+		return d.Level
 	}
 }
 
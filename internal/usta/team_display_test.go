@@ -1,6 +1,8 @@
 package usta
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -168,3 +170,163 @@ func TestDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayFamilies(t *testing.T) {
+	tests := map[string]struct {
+		name        string
+		code        string
+		family      League
+		ageDivision string
+		gender      Gender
+		level       string
+		teamSuffix  string
+		daytime     bool
+	}{
+		"adult": {
+			name:        "2026 Adult 40 & Over Womens 3.5",
+			family:      LeagueAdult,
+			ageDivision: "40+",
+			gender:      GenderWomens,
+			level:       "3.5",
+		},
+		"mixed": {
+			name:        "2026 Mixed 18 & Over 8.0",
+			family:      LeagueMixed,
+			ageDivision: "18+",
+			gender:      GenderMixed,
+			level:       "8.0",
+		},
+		"combo doubles": {
+			name:        "2026 Combo Doubles 18 & Over Womens 7.5",
+			family:      LeagueCombo,
+			ageDivision: "18+",
+			gender:      GenderWomens,
+			level:       "7.5",
+		},
+		"combo from code": {
+			name:        "2026 Adult 40 & Over Combo",
+			code:        "CLUB SR 40CM8.5B",
+			family:      LeagueCombo,
+			ageDivision: "40+",
+			gender:      GenderMens,
+			level:       "8.5",
+			teamSuffix:  "B",
+		},
+		"tri-level": {
+			name:        "2026 Tri-Level 18 & Over Womens 3.0/3.5/4.0",
+			family:      LeagueTriLevel,
+			ageDivision: "18+",
+			gender:      GenderWomens,
+			level:       "3.0/3.5/4.0",
+		},
+		"tri-level without levels": {
+			name:   "2026 Tri-Level Mens",
+			family: LeagueTriLevel,
+			gender: GenderMens,
+			level:  "Tri-Level",
+		},
+		"junior": {
+			name:        "2026 JTT 14 & Under Intermediate",
+			family:      LeagueJunior,
+			ageDivision: "14U",
+			gender:      GenderMixed,
+			level:       "Intermediate",
+		},
+		"junior girls": {
+			name:        "2026 Junior Team Tennis 18U Advanced Girls",
+			family:      LeagueJunior,
+			ageDivision: "18U",
+			gender:      GenderWomens,
+			level:       "Advanced",
+		},
+		"flex": {
+			name:   "2026 Flex Singles Mens 4.0",
+			family: LeagueFlex,
+			gender: GenderMens,
+			level:  "4.0",
+		},
+		"daytime from code": {
+			name:        "2026 Daytime Team",
+			code:        "ALMADEN SR 18AW2.5+A-DT",
+			family:      LeagueDaytime,
+			ageDivision: "18+",
+			gender:      GenderWomens,
+			level:       "2.5+",
+			teamSuffix:  "A",
+			daytime:     true,
+		},
+		"code only": {
+			name:        "Some Team",
+			code:        "CLUB SR 55MX6.0A",
+			family:      LeagueMixed,
+			ageDivision: "55+",
+			gender:      GenderMixed,
+			level:       "6.0",
+			teamSuffix:  "A",
+		},
+	}
+
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			team := &Team{Name: tc.name, Code: tc.code}
+			d := team.Display()
+			require.Equal(t, tc.family, d.Family)
+			require.Equal(t, tc.ageDivision, d.AgeDivision)
+			require.Equal(t, tc.gender, d.Gender)
+			require.Equal(t, tc.level, d.Level)
+			require.Equal(t, tc.teamSuffix, d.TeamSuffix)
+			require.Equal(t, tc.daytime, d.Daytime)
+		})
+	}
+}
+
+func TestDisplayLabel(t *testing.T) {
+	team := &Team{
+		ID:          7,
+		Name:        "2026 Mixed 40 & Over 7.0",
+		Code:        "CLUB SR 40MX7.0B [Team Bee's]",
+		CaptainName: "Jane Q. Nguyen",
+	}
+	plain := &Team{ID: 8, Name: "2026 Adult 18+ Womens 3.5", Code: "CLUB SR 18AW3.5A"}
+
+	tests := map[LabelStyle][2]string{
+		LabelLevel:    {"7.0", "3.5"},
+		LabelAgeLevel: {"40+ 7.0", "18+ 3.5"},
+		LabelNickname: {"Team Bee's", "3.5"},
+		LabelCaptain:  {"Nguyen", "3.5"},
+	}
+	for style, want := range tests {
+		t.Run(string(style), func(t *testing.T) {
+			SetNamingPolicy(NamingPolicy{Label: style})
+			defer SetNamingPolicy(DefaultNamingPolicy)
+
+			require.Equal(t, want[0], team.Display().Label())
+			require.Equal(t, want[1], plain.Display().Label())
+		})
+	}
+
+	SetNamingPolicy(NamingPolicy{Label: LabelLevel, Teams: map[int]LabelStyle{7: LabelCaptain}})
+	defer SetNamingPolicy(DefaultNamingPolicy)
+	require.Equal(t, "Nguyen", team.Display().Label())
+	require.Equal(t, "3.5", plain.Display().Label())
+}
+
+func TestLoadNamingPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := LoadNamingPolicy(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultNamingPolicy, p)
+
+	path := filepath.Join(dir, "naming.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("label: age-level\nteams:\n  98765: nickname\n"), 0644))
+	p, err = LoadNamingPolicy(path)
+	require.NoError(t, err)
+	require.Equal(t, LabelAgeLevel, p.Label)
+	require.Equal(t, LabelNickname, p.style(98765))
+	require.Equal(t, LabelAgeLevel, p.style(1))
+
+	require.NoError(t, os.WriteFile(path, []byte("label: initials\n"), 0644))
+	_, err = LoadNamingPolicy(path)
+	require.ErrorContains(t, err, `unknown label style "initials"`)
+}
@@ -0,0 +1,198 @@
+package usta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// League is the type of USTA league a team plays in.
+type League string
+
+const (
+	LeagueUnknown  League = ""
+	LeagueAdult    League = "Adult"
+	LeagueMixed    League = "Mixed"
+	LeagueCombo    League = "Combo"
+	LeagueTriLevel League = "Tri-Level"
+	LeagueDaytime  League = "Daytime"
+	LeagueJunior   League = "Junior"
+	LeagueFlex     League = "Flex"
+)
+
+var (
+	// seasonRegex matches the season year leading a team name, e.g. "2026".
+	seasonRegex = regexp.MustCompile(`^\s*((?:19|20)\d\d)\b`)
+	// ageDivisionRegex matches the age division in a team name, e.g.
+	// "18 & Over", "40+" or "55 & Over".
+	ageDivisionRegex = regexp.MustCompile(`(?i)\b(\d{2})(?:\s*\+|\s+&\s+Over)`)
+	// juniorAgeRegex matches the age division of a junior team, e.g.
+	// "14 & Under" or "12U".
+	juniorAgeRegex = regexp.MustCompile(`(?i)\b(\d{2})(?:\s*U|\s+&\s+Under)\b`)
+	// labeledValueRegex matches "Label: value" text on the team page, where
+	// the value runs until the next label or the end of the text.
+	labeledValueRegex = regexp.MustCompile(`(?i)\b(Season|Year|League|Age Division|Age Group|Area|Flight|Captain|Co-Captain)\s*:\s*(.+?)\s*(?:\b(?:Season|Year|League|Age Division|Age Group|Area|Flight|Captain|Co-Captain|Phone|Email)\s*:|$)`)
+)
+
+// parseMetadata fills the team's typed metadata from the labelled fields on
+// its page. The season, league and age division fall back to the team name
+// when the page doesn't label them.
+func (t *Team) parseMetadata(doc *goquery.Document) {
+	doc.Find("a").EachWithBreak(func(i int, sel *goquery.Selection) bool {
+		href, _ := sel.Attr("href")
+		if strings.HasPrefix(href, "flightresults.asp?") {
+			t.FlightID, _ = parseIDParam(href)
+			t.FlightName = cellText(sel)
+			return false
+		}
+		return true
+	})
+
+	doc.Find("td").Each(func(i int, sel *goquery.Selection) {
+		// Only look at innermost cells so labels aren't matched against the
+		// text of a whole nested table.
+		if sel.Find("td").Length() > 0 {
+			return
+		}
+		text := cellText(sel)
+		for _, m := range labeledValueRegex.FindAllStringSubmatch(text, -1) {
+			switch strings.ToLower(m[1]) {
+			case "season", "year":
+				if t.Season == 0 {
+					t.Season = parseSeason(m[2])
+				}
+			case "league":
+				if t.League == LeagueUnknown {
+					t.League = parseLeague(m[2])
+				}
+				if t.AgeDivision == "" {
+					t.AgeDivision = parseAgeDivision(m[2])
+				}
+			case "age division", "age group":
+				if t.AgeDivision == "" {
+					t.AgeDivision = parseAgeDivision(m[2])
+				}
+			case "area":
+				if t.Area == "" {
+					t.Area = m[2]
+				}
+			case "flight":
+				if t.FlightName == "" {
+					t.FlightName = m[2]
+				}
+			case "captain":
+				if t.CaptainName == "" {
+					t.CaptainName = m[2]
+					t.CaptainContact = captainEmail(sel)
+				}
+			}
+		}
+	})
+
+	if t.Season == 0 {
+		t.Season = parseSeason(t.Name)
+	}
+	if t.League == LeagueUnknown {
+		t.League = parseLeague(t.Name)
+	}
+	if t.AgeDivision == "" {
+		t.AgeDivision = parseAgeDivision(t.Name)
+	}
+}
+
+// season returns the team's season year, read from its name if it wasn't
+// loaded with the team.
+func (t *Team) season() int {
+	if t.Season != 0 {
+		return t.Season
+	}
+	return parseSeason(t.Name)
+}
+
+// league returns the team's league, read from its name if it wasn't loaded
+// with the team.
+func (t *Team) league() League {
+	if t.League != LeagueUnknown {
+		return t.League
+	}
+	return parseLeague(t.Name)
+}
+
+// ageDivision returns the team's age division, read from its name if it
+// wasn't loaded with the team.
+func (t *Team) ageDivision() string {
+	if t.AgeDivision != "" {
+		return t.AgeDivision
+	}
+	return parseAgeDivision(t.Name)
+}
+
+// captainEmail returns the email address linked in the cell holding the
+// captain label, or in the cell right after it, or "" if there is none. An
+// address elsewhere on the page may be anyone's.
+func captainEmail(label *goquery.Selection) string {
+	for _, sel := range []*goquery.Selection{label, label.NextFiltered("td")} {
+		if href, ok := sel.Find("a[href^='mailto:']").First().Attr("href"); ok {
+			return strings.TrimPrefix(href, "mailto:")
+		}
+	}
+	return ""
+}
+
+// captainFromRoster fills the captain's name from the roster if the team page
+// didn't label one.
+func (t *Team) captainFromRoster() {
+	if t.CaptainName != "" {
+		return
+	}
+	for _, p := range t.Players {
+		if p.Captain {
+			t.CaptainName = p.Name()
+			return
+		}
+	}
+}
+
+func parseSeason(name string) int {
+	m := seasonRegex.FindStringSubmatch(name)
+	if m == nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(m[1])
+	return year
+}
+
+func parseLeague(name string) League {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "junior") || strings.Contains(lower, "jtt") || juniorAgeRegex.MatchString(name):
+		return LeagueJunior
+	case strings.Contains(lower, "flex"):
+		return LeagueFlex
+	case strings.Contains(lower, "tri-level") || strings.Contains(lower, "tri level"):
+		return LeagueTriLevel
+	case strings.Contains(lower, "combo"):
+		return LeagueCombo
+	case strings.Contains(lower, "daytime"):
+		return LeagueDaytime
+	case strings.Contains(lower, "mixed"):
+		return LeagueMixed
+	case strings.Contains(lower, "adult"):
+		return LeagueAdult
+	default:
+		return LeagueUnknown
+	}
+}
+
+func parseAgeDivision(name string) string {
+	if m := juniorAgeRegex.FindStringSubmatch(name); m != nil {
+		return m[1] + "U"
+	}
+	m := ageDivisionRegex.FindStringSubmatch(name)
+	if m == nil {
+		return ""
+	}
+	return m[1] + "+"
+}
@@ -0,0 +1,313 @@
+package usta
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// ErrNoScheduleTable is returned when a team page has no table with a
+	// recognizable schedule header row.
+	ErrNoScheduleTable = errors.New("no schedule table found")
+	// ErrNotMatchRow marks rows of the schedule table that aren't matches,
+	// e.g. section headings. They are skipped without being reported.
+	ErrNotMatchRow = errors.New("not a match row")
+	// ErrMissingCell is returned for a match row that has no cell under one
+	// of the schedule's columns.
+	ErrMissingCell = errors.New("missing cell")
+
+	// scheduleDateRegex matches a schedule date, e.g. "04/13/26".
+	scheduleDateRegex = regexp.MustCompile(`\d\d/\d\d/\d\d`)
+)
+
+// RowParseError describes a row of a team's schedule table that couldn't be
+// parsed into a match.
+type RowParseError struct {
+	// Row is the row's index within the schedule table, counting the header
+	// row as 0.
+	Row int
+	// Column is the header of the column whose cell couldn't be parsed, if
+	// any.
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *RowParseError) Error() string {
+	s := fmt.Sprintf("row %d", e.Row)
+	if e.Column != "" {
+		s += fmt.Sprintf(", column %q", e.Column)
+	}
+	if e.Value != "" {
+		s += fmt.Sprintf(", value %q", e.Value)
+	}
+	return s + ": " + e.Err.Error()
+}
+
+func (e *RowParseError) Unwrap() error {
+	return e.Err
+}
+
+// ScheduleEntry is a match as read from one row of a team's schedule table,
+// from the team's point of view.
+type ScheduleEntry struct {
+	Number     int
+	NumberText string
+	Type       MatchType
+	Date       time.Time
+	HasTime    bool
+	Schedule   Schedule
+
+	OpponentID   int
+	OpponentName string
+	Home         bool
+
+	// OutcomeVerb is "Won" or "Lost" from the team's point of view, or empty
+	// if no team won.
+	OutcomeVerb  string
+	OutcomeKind  OutcomeKind
+	WinnerPoints int
+	LoserPoints  int
+
+	ScorecardID int
+}
+
+// ScheduleRow is one row of a team's schedule table: its cell text and either
+// the parsed match or the reason the row was skipped.
+type ScheduleRow struct {
+	Index int
+	Cells []string
+	Entry *ScheduleEntry
+	Err   error
+}
+
+// scheduleColumns holds the column index of each schedule field, or -1 if
+// the schedule table has no such column.
+type scheduleColumns struct {
+	number, date, time, opponent, location, outcome int
+	headers                                         []string
+}
+
+// header returns the header text of column i.
+func (c *scheduleColumns) header(i int) string {
+	if i < 0 || i >= len(c.headers) {
+		return ""
+	}
+	return c.headers[i]
+}
+
+// ScheduleRows parses every row of the team's schedule table. Rows that
+// couldn't be parsed have Err set; rows that aren't matches at all have an
+// Err wrapping ErrNotMatchRow.
+func (t *Team) ScheduleRows() ([]ScheduleRow, error) {
+	if t.doc == nil {
+		return nil, fmt.Errorf("team %d page not loaded", t.ID)
+	}
+	return parseScheduleTable(t.doc, t.FlightName)
+}
+
+// parseScheduleTable finds the schedule table by its header row, which must
+// have date and opponent columns, and parses the rows after it.
+func parseScheduleTable(doc *goquery.Document, flightName string) ([]ScheduleRow, error) {
+	var header *goquery.Selection
+	var cols *scheduleColumns
+
+	doc.Find("tr").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		cells := row.ChildrenFiltered("td,th")
+		// Skip rows of the layout tables wrapping the schedule.
+		if cells.Find("tr").Length() > 0 {
+			return true
+		}
+		if cols = scheduleHeader(cells); cols != nil {
+			header = row
+			return false
+		}
+		return true
+	})
+
+	if cols == nil {
+		return nil, ErrNoScheduleTable
+	}
+
+	var rows []ScheduleRow
+	header.NextAllFiltered("tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.ChildrenFiltered("td,th")
+
+		sr := ScheduleRow{Index: i + 1}
+		cells.Each(func(i int, cell *goquery.Selection) {
+			sr.Cells = append(sr.Cells, cellText(cell))
+		})
+
+		entry, err := parseScheduleRow(cells, cols, flightName)
+		if err != nil {
+			var rowErr *RowParseError
+			if !errors.As(err, &rowErr) {
+				rowErr = &RowParseError{Err: err}
+			}
+			rowErr.Row = sr.Index
+			sr.Err = rowErr
+		}
+		sr.Entry = entry
+
+		rows = append(rows, sr)
+	})
+
+	return rows, nil
+}
+
+// scheduleHeader returns the schedule column positions if cells is the
+// schedule table's header row, or nil otherwise.
+func scheduleHeader(cells *goquery.Selection) *scheduleColumns {
+	cols := &scheduleColumns{-1, -1, -1, -1, -1, -1, nil}
+
+	cells.Each(func(i int, cell *goquery.Selection) {
+		text := cellText(cell)
+		cols.headers = append(cols.headers, text)
+
+		h := strings.ToLower(text)
+		switch {
+		case strings.Contains(h, "date"):
+			cols.date = i
+		case strings.Contains(h, "time"):
+			cols.time = i
+		case strings.Contains(h, "opponent") || strings.Contains(h, "opposing"):
+			cols.opponent = i
+		case strings.Contains(h, "home") || strings.Contains(h, "location") || h == "h/a" || h == "site":
+			cols.location = i
+		case strings.Contains(h, "result") || strings.Contains(h, "outcome") || strings.Contains(h, "score"):
+			cols.outcome = i
+		case strings.Contains(h, "match") || h == "#" || h == "no." || h == "round":
+			cols.number = i
+		}
+	})
+
+	if cols.date < 0 || cols.opponent < 0 {
+		return nil
+	}
+	return cols
+}
+
+// parseScheduleRow parses one row of the schedule table.
+func parseScheduleRow(cells *goquery.Selection, cols *scheduleColumns, flightName string) (*ScheduleEntry, error) {
+	// Section headings and spacers span the whole table in a single cell.
+	if cells.Length() <= 1 {
+		return nil, ErrNotMatchRow
+	}
+
+	cell := func(col int) (*goquery.Selection, error) {
+		if col < 0 {
+			return nil, nil
+		}
+		if col >= cells.Length() {
+			return nil, &RowParseError{Column: cols.header(col), Err: ErrMissingCell}
+		}
+		return cells.Eq(col), nil
+	}
+	cellErr := func(col int, value string, err error) error {
+		return &RowParseError{Column: cols.header(col), Value: value, Err: err}
+	}
+
+	e := &ScheduleEntry{}
+
+	// Match number, e.g. "2 (4/13-4/19) Full RR#1" → 2
+	if c, err := cell(cols.number); err != nil {
+		return nil, err
+	} else if c != nil {
+		e.NumberText = cellText(c)
+		if fields := strings.Fields(e.NumberText); len(fields) > 0 {
+			e.Number, _ = strconv.Atoi(fields[0])
+		}
+	}
+
+	// Date. A rescheduled match shows its original date before the new one,
+	// so use the last date in the cell.
+	c, err := cell(cols.date)
+	if err != nil {
+		return nil, err
+	}
+	v := cellText(c)
+	dates := scheduleDateRegex.FindAllString(v, -1)
+	if len(dates) == 0 {
+		return nil, cellErr(cols.date, v, errors.New("no date"))
+	}
+	e.Date, err = time.ParseInLocation("01/02/06", dates[len(dates)-1], tz)
+	if err != nil {
+		return nil, cellErr(cols.date, v, err)
+	}
+
+	// Time, start times, courts and notes
+	var timeText string
+	if c, err := cell(cols.time); err != nil {
+		return nil, err
+	} else if c != nil {
+		timeText = cellText(c)
+		hour, minute, err := parseTime(timeText)
+		if err != nil {
+			return nil, cellErr(cols.time, timeText, err)
+		}
+		if hour > 0 {
+			e.HasTime = true
+			e.Date = time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), hour, minute, 0, 0, e.Date.Location())
+		}
+		e.Schedule = parseSchedule(timeText)
+	}
+
+	// Classify the match from the match number text, the flight and the time
+	// format
+	e.Type = classifyMatch(e.NumberText, flightName, timeText)
+
+	// Opposing team
+	c, err = cell(cols.opponent)
+	if err != nil {
+		return nil, err
+	}
+	link := c.Find("a[href*='teaminfo.asp']").First()
+	if link.Length() == 0 {
+		return nil, cellErr(cols.opponent, cellText(c), errors.New("no team link"))
+	}
+	href, _ := link.Attr("href")
+	e.OpponentID, err = parseTeamID(href)
+	if err != nil {
+		return nil, cellErr(cols.opponent, href, err)
+	}
+	e.OpponentName = cellText(link)
+
+	// Location (home or away)
+	if c, err := cell(cols.location); err != nil {
+		return nil, err
+	} else if c != nil {
+		switch v := cellText(c); strings.ToLower(v) {
+		case "home", "h":
+			e.Home = true
+		case "away", "a":
+		default:
+			return nil, cellErr(cols.location, v, errors.New("expected Home or Away"))
+		}
+	}
+
+	// Outcome
+	if c, err := cell(cols.outcome); err != nil {
+		return nil, err
+	} else if c != nil {
+		v := cellText(c)
+		e.OutcomeVerb, e.OutcomeKind, e.WinnerPoints, e.LoserPoints, err = parseOutcome(v)
+		if err != nil {
+			return nil, cellErr(cols.outcome, v, err)
+		}
+	}
+
+	// Scorecard ID, if the match has been played
+	cells.Find("a[href*='scorecard.asp']").EachWithBreak(func(i int, a *goquery.Selection) bool {
+		href, _ := a.Attr("href")
+		e.ScorecardID, _ = parseIDParam(href)
+		return e.ScorecardID == 0
+	})
+
+	return e, nil
+}
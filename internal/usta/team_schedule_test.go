@@ -0,0 +1,95 @@
+package usta
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testScheduleHTML follows the layout of a teaminfo.asp page: the schedule
+// is a table nested in a layout table, match rows are colored white, or
+// #D2D2FF for playoffs, and each has a leading blank cell before the match
+// number. A rescheduled date keeps the old date before the new one.
+const testScheduleHTML = `<html><body>
+<table width="100%"><tbody><tr><td>
+<table border="0" cellpadding="2" cellspacing="0" width="100%"><tbody>
+  <tr bgcolor="#CCCCCC"><td>&nbsp;</td><td><b>Match #</b></td><td><b>Match Date</b></td><td><b>Day</b></td><td><b>Match Time</b></td><td><b>Opponent</b></td><td><b>Home/Away</b></td><td><b>Results</b></td></tr>
+  <tr bgcolor="white"><td>&nbsp;</td><td>2 (4/13-4/19) Full RR#1</td><td>04/13/26 <font color="red">04/15/26</font></td><td>Wed</td><td>All 3 at 7:30 PM Courts 1, 2 and 3</td><td><a href="teaminfo.asp?id=111">COURTSIDE 18AW3.5</a></td><td>Home</td><td><a href="scorecard.asp?id=999">Won 3-2</a></td></tr>
+  <tr><td colspan="8"><b>Playoffs</b></td></tr>
+  <tr bgcolor="#D2D2FF"><td>&nbsp;</td><td>PO#1</td><td>05/20/26</td><td>Wed</td><td>08:00:00</td><td><a href="teaminfo.asp?id=222">SUNNYVALE 18AW3.5</a></td><td>Away</td><td>&nbsp;</td></tr>
+  <tr bgcolor="white"><td>&nbsp;</td><td>3</td><td>TBD</td><td>Sat</td><td>&nbsp;</td><td><a href="teaminfo.asp?id=333">VILLAGES</a></td><td>Home</td><td>&nbsp;</td></tr>
+  <tr bgcolor="white"><td>&nbsp;</td><td>4</td><td>04/25/26</td><td>Sat</td><td>&nbsp;</td><td>Bye</td><td>Home</td><td>&nbsp;</td></tr>
+  <tr bgcolor="white"><td>&nbsp;</td><td>5</td><td>05/02/26</td><td>Sat</td></tr>
+</tbody></table>
+</td></tr></tbody></table>
+</body></html>`
+
+func TestParseScheduleTable(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testScheduleHTML))
+	require.NoError(t, err)
+
+	rows, err := parseScheduleTable(doc, "4.0 Flight 1")
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+
+	played := rows[0]
+	require.NoError(t, played.Err)
+	require.Equal(t, 2, played.Entry.Number)
+	require.Equal(t, MatchTypeRegular, played.Entry.Type)
+	require.Equal(t, time.Date(2026, 4, 15, 19, 30, 0, 0, tz), played.Entry.Date)
+	require.True(t, played.Entry.HasTime)
+	require.Equal(t, []string{"1", "2", "3"}, played.Entry.Schedule.Courts)
+	require.Equal(t, 111, played.Entry.OpponentID)
+	require.Equal(t, "COURTSIDE 18AW3.5", played.Entry.OpponentName)
+	require.True(t, played.Entry.Home)
+	require.Equal(t, "Won", played.Entry.OutcomeVerb)
+	require.Equal(t, 3, played.Entry.WinnerPoints)
+	require.Equal(t, 999, played.Entry.ScorecardID)
+
+	require.ErrorIs(t, rows[1].Err, ErrNotMatchRow)
+
+	playoff := rows[2]
+	require.NoError(t, playoff.Err)
+	require.Equal(t, MatchTypePlayoff, playoff.Entry.Type)
+	require.False(t, playoff.Entry.Home)
+	require.Equal(t, OutcomeUnknown, playoff.Entry.OutcomeKind)
+
+	var rowErr *RowParseError
+	require.True(t, errors.As(rows[3].Err, &rowErr))
+	require.Equal(t, 4, rowErr.Row)
+	require.Equal(t, "Match Date", rowErr.Column)
+	require.Equal(t, "TBD", rowErr.Value)
+
+	require.True(t, errors.As(rows[4].Err, &rowErr))
+	require.Equal(t, "Opponent", rowErr.Column)
+
+	require.ErrorIs(t, rows[5].Err, ErrMissingCell)
+}
+
+func TestParseScheduleTableWithoutHeader(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<table><tr><td>nothing here</td></tr></table>`))
+	require.NoError(t, err)
+
+	_, err = parseScheduleTable(doc, "")
+	require.ErrorIs(t, err, ErrNoScheduleTable)
+}
+
+func TestLoadMatchesReportsMissingScheduleTable(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<table><tr><td>nothing here</td></tr></table>`))
+	require.NoError(t, err)
+	team := &Team{ID: 42, doc: doc}
+
+	report := NewLoadReport()
+	_, err = team.LoadMatches(WithLoadReport(context.Background(), report))
+	require.NoError(t, err)
+	require.Empty(t, team.Matches)
+	require.Len(t, report.Failures(), 1)
+	require.Equal(t, "schedule table", report.Failures()[0].What)
+	require.ErrorIs(t, report.Failures()[0].Err, ErrNoScheduleTable)
+	require.Error(t, report.Err())
+}
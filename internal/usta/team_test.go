@@ -1,8 +1,12 @@
 package usta
 
 import (
-	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseTime(t *testing.T) {
@@ -42,3 +46,156 @@ func TestParseTime(t *testing.T) {
 		})
 	}
 }
+
+const testTeamMetadataHTML = `<html><body>
+<table><tbody><tr><td><b>2026 Adult 55 &amp; Over Womens 3.0 Daytime</b> ALMADEN SR 55AW3.0-DT</td></tr></tbody></table>
+<table>
+  <tr><td>Area: South Bay</td></tr>
+  <tr><td>Flight: <a href="flightresults.asp?id=4321">Flight 3</a></td></tr>
+  <tr><td>Captain: Jane Smith Email: <a href="mailto:jane@example.com">jane@example.com</a></td></tr>
+</table>
+</body></html>`
+
+func TestParseMetadata(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(testTeamMetadataHTML))
+	require.NoError(t, err)
+
+	team := &Team{Name: "2026 Adult 55 & Over Womens 3.0 Daytime"}
+	team.parseMetadata(doc)
+
+	require.Equal(t, 2026, team.Season)
+	require.Equal(t, LeagueDaytime, team.League)
+	require.Equal(t, "55+", team.AgeDivision)
+	require.Equal(t, "South Bay", team.Area)
+	require.Equal(t, 4321, team.FlightID)
+	require.Equal(t, "Flight 3", team.FlightName)
+	require.Equal(t, "Jane Smith", team.CaptainName)
+	require.Equal(t, "jane@example.com", team.CaptainContact)
+	require.True(t, team.Display().Daytime)
+}
+
+func TestParseMetadataLabeledFields(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body>
+<table>
+  <tr><td>Season: 2025</td></tr>
+  <tr><td>League: Mixed 40 &amp; Over</td></tr>
+  <tr><td>Area: South Bay</td></tr>
+</table>
+</body></html>`))
+	require.NoError(t, err)
+
+	// The page's labels win over the name.
+	team := &Team{Name: "Almaden Adult 18 & Over Womens 3.5"}
+	team.parseMetadata(doc)
+
+	require.Equal(t, 2025, team.Season)
+	require.Equal(t, LeagueMixed, team.League)
+	require.Equal(t, "40+", team.AgeDivision)
+	require.Equal(t, "South Bay", team.Area)
+}
+
+func TestShortNameStripsSeason(t *testing.T) {
+	team := &Team{Name: "Adult 18 & Over Womens 3.5 2025", Season: 2025}
+	require.Equal(t, "Adult 18+ Womens 3.5", team.ShortName())
+}
+
+func TestParseMetadataCaptainEmail(t *testing.T) {
+	cases := map[string]struct {
+		html    string
+		contact string
+	}{
+		"next cell": {
+			html:    `<table><tr><td>Captain: Jane Smith</td><td><a href="mailto:jane@example.com">jane@example.com</a></td></tr></table>`,
+			contact: "jane@example.com",
+		},
+		"elsewhere on the page": {
+			html: `<table><tr><td><a href="mailto:webmaster@ustanorcal.com">Contact us</a></td></tr>
+<tr><td>Captain: Jane Smith</td></tr></table>`,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(test.html))
+			require.NoError(t, err)
+
+			team := &Team{}
+			team.parseMetadata(doc)
+			require.Equal(t, "Jane Smith", team.CaptainName)
+			require.Equal(t, test.contact, team.CaptainContact)
+		})
+	}
+}
+
+func TestParseLeague(t *testing.T) {
+	cases := map[string]League{
+		"2026 Adult 18 & Over Womens 3.5":         LeagueAdult,
+		"2026 Adult 40 & Over Mixed 7.0":          LeagueMixed,
+		"2026 Adult 18 & Over Combo Mens 7.5":     LeagueCombo,
+		"2026 Tri-Level Womens 3.5":               LeagueTriLevel,
+		"2026 Adult 18 & Over Womens 3.0 Daytime": LeagueDaytime,
+		"Something else":                          LeagueUnknown,
+	}
+
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			require.Equal(t, expected, parseLeague(input))
+		})
+	}
+}
+
+func TestParseOutcome(t *testing.T) {
+	cases := map[string]struct {
+		verb         string
+		kind         OutcomeKind
+		winnerPoints int
+		loserPoints  int
+	}{
+		"Won 3-2":                    {"Won", OutcomePlayed, 3, 2},
+		"Lost 1-4":                   {"Lost", OutcomePlayed, 4, 1},
+		"Won 5-0 (Default)":          {"Won", OutcomeDefault, 5, 0},
+		"Lost by Default":            {"Lost", OutcomeDefault, 0, 0},
+		"Won by Forfeit":             {"Won", OutcomeForfeit, 0, 0},
+		"Won 3-2 (Retired)":          {"Won", OutcomeRetired, 3, 2},
+		"Rained Out":                 {"", OutcomeRainout, 0, 0},
+		"Postponed":                  {"", OutcomePostponed, 0, 0},
+		"":                           {"", OutcomeUnknown, 0, 0},
+		"To be played 4/20":          {"", OutcomeUnknown, 0, 0},
+		"  Won   3-2  ":              {"Won", OutcomePlayed, 3, 2},
+		"Lost 2-3 (Forfeit)":         {"Lost", OutcomeForfeit, 3, 2},
+		"Won 4-1 Def. line 5":        {"Won", OutcomePlayed, 4, 1},
+		"Won 3-2 (rain delay)":       {"Won", OutcomePlayed, 3, 2},
+		"Lost 2-3 ppd line 5":        {"Lost", OutcomePlayed, 3, 2},
+		"Won 3-2 line 2 ret.":        {"Won", OutcomePlayed, 3, 2},
+		"Rain Out":                   {"", OutcomeRainout, 0, 0},
+		"PPD":                        {"", OutcomePostponed, 0, 0},
+		"Lost 2-3 (Def.)":            {"Lost", OutcomeDefault, 3, 2},
+		"Won 3-2 (line 4 forfeited)": {"Won", OutcomePlayed, 3, 2},
+	}
+
+	for input, test := range cases {
+		t.Run(input, func(t *testing.T) {
+			verb, kind, winnerPoints, loserPoints, err := parseOutcome(input)
+			require.NoError(t, err)
+			require.Equal(t, test.verb, verb)
+			require.Equal(t, test.kind, kind)
+			require.Equal(t, test.winnerPoints, winnerPoints)
+			require.Equal(t, test.loserPoints, loserPoints)
+		})
+	}
+}
+
+func TestTeamShortNameStripsSeason(t *testing.T) {
+	team := &Team{Name: "2025 Adult 18 & Over Womens 3.5"}
+	require.Equal(t, "Adult 18+ Womens 3.5", team.ShortName())
+
+	// A season spanning two calendar years, named without a leading year.
+	team = &Team{
+		Name: "Adult 40 & Over Mens 4.0 Winter 2025",
+		Matches: []Match{
+			{Date: time.Date(2025, 12, 6, 9, 0, 0, 0, tz)},
+			{Date: time.Date(2026, 1, 10, 9, 0, 0, 0, tz)},
+		},
+	}
+	require.Equal(t, "Adult 40+ Mens 4.0 Winter", team.ShortName())
+}
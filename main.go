@@ -5,17 +5,21 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/ycombinator/usta-norcal-club-newsletter/internal"
 	"github.com/ycombinator/usta-norcal-club-newsletter/internal/core"
 	"github.com/ycombinator/usta-norcal-club-newsletter/internal/formatters"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
 )
 
 func usage() {
@@ -31,12 +35,28 @@ Flags:
 Examples:
   usta-norcal-club-newsletter                                        Use defaults (ASRC, jpeg)
   usta-norcal-club-newsletter -org=300                               Specify a different organization
+  usta-norcal-club-newsletter -org="almaden swim"                    Specify an organization by name
+  usta-norcal-club-newsletter -org=225,300                           A newsletter for each of two clubs
+  usta-norcal-club-newsletter -org=225,300 -layout=combined          One newsletter with a section per club
   usta-norcal-club-newsletter -teams=123,456                         Track additional teams by ID
+  usta-norcal-club-newsletter -group=seniors                         Only cover the 55+ and 65+ teams
   usta-norcal-club-newsletter -format=console                        Console output for both sections
   usta-norcal-club-newsletter -recent-format=jpeg -upcoming-format=console
   usta-norcal-club-newsletter -upcoming-format=gcal -gcal-credentials=creds.json -gcal-calendar="USTA Tennis"
   usta-norcal-club-newsletter -past=7 -future=14                     Show 7 days back and 14 days ahead
+  usta-norcal-club-newsletter -display-tz=America/New_York           Show match times in Eastern time
   usta-norcal-club-newsletter -outdir=./output                       Write files to ./output
+  usta-norcal-club-newsletter -concurrency=4 -rps=2                  Fetch from USTA more gently
+  usta-norcal-club-newsletter -progress=json                         Report loading progress as JSON lines
+  usta-norcal-club-newsletter -record=./pages                        Save the USTA pages used by this run
+  usta-norcal-club-newsletter -replay=./pages                        Re-run offline from saved pages
+  usta-norcal-club-newsletter -org=1 -league-file=league.yaml        Use a league kept in a file
+  usta-norcal-club-newsletter -cache-ttl=team=30m,scorecard=168h     Override on-disk cache TTLs
+  usta-norcal-club-newsletter -state-file=""                         Don't track rescheduled matches
+  usta-norcal-club-newsletter cache stats                            Show on-disk cache statistics
+  usta-norcal-club-newsletter cache clear                            Remove all cached pages
+  usta-norcal-club-newsletter orgs search almaden swim               Find an organization's ID by name
+  usta-norcal-club-newsletter diagnose-team 98765                    Show how a team page's schedule is parsed
   usta-norcal-club-newsletter help                                   Show this help message
 `)
 }
@@ -86,7 +106,8 @@ func main() {
 	c := internal.DefaultConfig()
 
 	flag.Usage = usage
-	orgID := flag.Int("org", c.OrganizationID, "USTA NorCal organization ID")
+	org := flag.String("org", joinIDs(c.OrganizationIDs), "comma-separated list of USTA NorCal organization IDs, or names each matching exactly one organization")
+	layout := flag.String("layout", string(c.Layout), "with several organizations: separate (a newsletter per club) or combined (one newsletter with a section per club)")
 	teams := flag.String("teams", "", "comma-separated list of additional team IDs to track")
 	format := flag.String("format", "jpeg", "output format for both sections: console, pdf, jpeg, or html")
 	recentFormat := flag.String("recent-format", "", "output format for recent results (overrides -format)")
@@ -94,9 +115,28 @@ func main() {
 	pastDays := flag.Int("past", int(c.PastDuration.Hours()/24), "number of days back to include past match results")
 	futureDays := flag.Int("future", int(c.FutureDuration.Hours()/24), "number of days ahead to include upcoming matches")
 	outDir := flag.String("outdir", "", "output directory for file-based formatters")
+	timeZone := flag.String("tz", c.TimeZone, "league time zone match times are read and windowed in")
+	displayTimeZone := flag.String("display-tz", "", "time zone to show match times in (default: the league time zone)")
 	boundaryDate := flag.String("boundary-date", "", "date (YYYY-MM-DD) dividing recent and upcoming matches (default: tomorrow)")
 	gcalCredentials := flag.String("gcal-credentials", "", "path to Google OAuth2 client credentials JSON (required for gcal format)")
 	gcalCalendar := flag.String("gcal-calendar", "", "Google Calendar name for upcoming match events (required for gcal format)")
+	leagueFile := flag.String("league-file", "", "JSON or YAML league file to load instead of the USTA NorCal site")
+	group := flag.String("group", "", "only cover the teams in this named group, e.g. seniors (see README)")
+	groupsFile := flag.String("groups-file", "groups.yaml", "YAML file of named team groups for -group")
+	namingFile := flag.String("naming-file", "naming.yaml", "YAML naming policy deciding how our teams are labelled (see README)")
+	showGateCodes := flag.Bool("show-gate-codes", false, "include facility gate codes in calendar events (default: redact them)")
+	progress := flag.String("progress", "auto", "progress display while loading: auto, bar, json (one JSON object per line on stderr) or none")
+	strict := flag.Bool("strict", false, "fail the run if any team, opponent or page fails to load (default: warn and continue)")
+	cacheDir := flag.String("cache-dir", c.CacheDir, "directory for cached USTA pages")
+	cacheTTL := flag.String("cache-ttl", "", "comma-separated kind=duration cache TTLs (kinds: organization, team, scorecard, flight, orglist)")
+	noCache := flag.Bool("no-cache", false, "disable the on-disk page cache")
+	stateFile := flag.String("state-file", c.StateFile, "file remembering each match's last-seen date, to flag rescheduled matches (empty to disable)")
+	recordDir := flag.String("record", "", "directory to save every USTA page used by this run, for replaying it later")
+	replayDir := flag.String("replay", "", "directory of pages saved with -record to use instead of the USTA site (no network access)")
+	concurrency := flag.Int("concurrency", c.Fetcher.Concurrency, "maximum number of concurrent requests to the USTA site")
+	rps := flag.Float64("rps", c.Fetcher.RequestsPerSecond, "maximum requests per second to the USTA site (0 for unlimited)")
+	retries := flag.Int("retries", c.Fetcher.MaxRetries, "number of times to retry a request failing with a 5xx or network error")
+	retryBackoff := flag.Duration("retry-backoff", c.Fetcher.RetryBackoff, "base delay before retrying a failed request")
 
 	// Handle "help" sub-command before flag.Parse
 	if len(os.Args) > 1 && os.Args[1] == "help" {
@@ -104,25 +144,178 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "diagnose-team" {
+		if err := runDiagnoseTeamCommand(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "orgs" {
+		if err := runOrgsCommand(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		if err := runCacheCommand(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.Parse()
 
-	c.OrganizationID = *orgID
 	c.PastDuration = time.Duration(*pastDays) * 24 * time.Hour
 	c.FutureDuration = time.Duration(*futureDays) * 24 * time.Hour
+	c.TimeZone = *timeZone
+	c.DisplayTimeZone = *displayTimeZone
+
+	if *noCache {
+		c.CacheDir = ""
+	} else {
+		c.CacheDir = *cacheDir
+	}
+	ttls, err := parseCacheTTLs(*cacheTTL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	c.CacheTTLs = ttls
+	if c.CacheDir != "" {
+		usta.SetDiskCache(usta.NewDiskCache(c.CacheDir, c.CacheTTLs))
+	}
+
+	c.Fetcher = usta.FetcherConfig{
+		Concurrency:       *concurrency,
+		RequestsPerSecond: *rps,
+		MaxRetries:        *retries,
+		RetryBackoff:      *retryBackoff,
+	}
+	usta.ConfigureFetcher(c.Fetcher)
+
+	if *recordDir != "" && *replayDir != "" {
+		fmt.Fprintln(os.Stderr, "-record and -replay cannot be used together")
+		os.Exit(1)
+	}
+	if *recordDir != "" {
+		usta.SetRecordArchive(usta.NewPageArchive(*recordDir))
+	}
+	if *replayDir != "" {
+		if _, err := os.Stat(*replayDir); err != nil {
+			fmt.Fprintln(os.Stderr, "cannot replay:", err)
+			os.Exit(1)
+		}
+		usta.SetReplayArchive(usta.NewPageArchive(*replayDir))
+	}
+
+	// Replayed pages are from an earlier run, so they mustn't update the
+	// last-seen match dates.
+	c.StateFile = *stateFile
+	var history *usta.ScheduleHistory
+	if c.StateFile != "" && *replayDir == "" {
+		history, err = usta.LoadScheduleHistory(c.StateFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		usta.SetScheduleHistory(history)
+	}
+
+	if *leagueFile != "" {
+		c.OrganizationIDs = nil
+		for _, s := range strings.Split(*org, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid organization ID %q: -league-file needs IDs\n", s)
+				os.Exit(1)
+			}
+			c.OrganizationIDs = append(c.OrganizationIDs, id)
+		}
+	} else {
+		c.OrganizationIDs, err = resolveOrganizationIDs(context.Background(), *org)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	c.Layout = internal.Layout(*layout)
+	if c.Layout != internal.LayoutSeparate && c.Layout != internal.LayoutCombined {
+		fmt.Fprintf(os.Stderr, "invalid -layout %q: use separate or combined\n", *layout)
+		os.Exit(1)
+	}
+
+	leagueLoc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -tz %q: %v\n", c.TimeZone, err)
+		os.Exit(1)
+	}
+	usta.SetTimeZone(leagueLoc)
+	var displayLoc *time.Location
+	if c.DisplayTimeZone != "" {
+		displayLoc, err = time.LoadLocation(c.DisplayTimeZone)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -display-tz %q: %v\n", c.DisplayTimeZone, err)
+			os.Exit(1)
+		}
+	}
+
+	naming, err := usta.LoadNamingPolicy(*namingFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	usta.SetNamingPolicy(naming)
+
+	var filter *core.TeamFilter
+	if *group != "" {
+		groups, err := core.LoadTeamGroups(*groupsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		f, ok := groups[*group]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown -group %q (groups: %s)\n", *group, strings.Join(slices.Sorted(maps.Keys(groups)), ", "))
+			os.Exit(1)
+		}
+		filter = &f
+	}
 
 	var parsedBoundary time.Time
 	if *boundaryDate != "" {
 		var err error
-		parsedBoundary, err = time.ParseInLocation("2006-01-02", *boundaryDate, time.Now().Location())
+		parsedBoundary, err = time.ParseInLocation("2006-01-02", *boundaryDate, leagueLoc)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "invalid boundary-date %q: expected YYYY-MM-DD\n", *boundaryDate)
 			os.Exit(1)
 		}
 	}
 
+	// A replayed run defaults to the boundary the recorded run had: the day
+	// after the pages were fetched.
+	runDate := time.Now().In(leagueLoc)
+	if *replayDir != "" {
+		fetchedAt, err := usta.NewPageArchive(*replayDir).FetchedAt()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "cannot replay:", err)
+			os.Exit(1)
+		}
+		runDate = fetchedAt.In(leagueLoc)
+		if parsedBoundary.IsZero() {
+			parsedBoundary = time.Date(runDate.Year(), runDate.Month(), runDate.Day()+1, 0, 0, 0, 0, leagueLoc)
+			slog.Info("replaying with the recorded run's boundary", "fetched_at", fetchedAt, "boundary", parsedBoundary.Format("2006-01-02"))
+		}
+	}
+
 	if *outDir == "" {
-		dirDate := time.Now()
-		if !parsedBoundary.IsZero() {
+		dirDate := runDate
+		if *boundaryDate != "" {
 			dirDate = parsedBoundary
 		}
 		*outDir = filepath.Join(
@@ -131,6 +324,11 @@ func main() {
 			dirDate.Format("20060102"),
 		)
 	}
+	// A group's newsletter goes in its own directory so that it doesn't
+	// share a data file with the whole club's.
+	if *group != "" {
+		*outDir = filepath.Join(*outDir, *group)
+	}
 
 	if *teams != "" {
 		for _, s := range strings.Split(*teams, ",") {
@@ -161,7 +359,6 @@ func main() {
 		effectiveUpcoming = *upcomingFormat
 	}
 
-	var err error
 	c.RecentFormatter, err = makeRecentFormatter(effectiveRecent)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -174,13 +371,18 @@ func main() {
 	}
 
 	slog.Info("starting newsletter generation",
-		"org", c.OrganizationID,
+		"orgs", c.OrganizationIDs,
+		"layout", c.Layout,
+		"group", *group,
 		"extra_teams", c.TeamIDs,
 		"recent_format", effectiveRecent,
 		"upcoming_format", effectiveUpcoming,
 		"past_days", *pastDays,
 		"future_days", *futureDays,
 		"outdir", *outDir,
+		"cache_dir", c.CacheDir,
+		"concurrency", c.Fetcher.Concurrency,
+		"rps", c.Fetcher.RequestsPerSecond,
 	)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -189,52 +391,121 @@ func main() {
 	dataFilePath := filepath.Join(*outDir, "data.json")
 
 	fmtCfg := formatters.Config{
-		OrganizationID: c.OrganizationID,
-		PastDuration:   c.PastDuration,
-		FutureDuration: c.FutureDuration,
-		BoundaryDate:   parsedBoundary,
-		OutputDir:      *outDir,
-		DataFilePath:   dataFilePath,
-		Reader:         os.Stdin,
-		Writer:         os.Stdout,
+		OrganizationID:  c.OrganizationIDs[0],
+		PastDuration:    c.PastDuration,
+		FutureDuration:  c.FutureDuration,
+		BoundaryDate:    parsedBoundary,
+		OutputDir:       *outDir,
+		DataFilePath:    dataFilePath,
+		ShowGateCodes:   *showGateCodes,
+		DisplayLocation: displayLoc,
+		Reader:          os.Stdin,
+		Writer:          os.Stdout,
 	}
 
-	n, err := core.NewNewsletter(c.OrganizationID, c.TeamIDs)
+	n, err := core.NewNewsletter(c.OrganizationIDs, c.TeamIDs)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	n.SetStrict(*strict)
+	if filter != nil {
+		n.SetFilter(*filter)
+	}
+	reportProgress, finishProgress, err := newProgressReporter(*progress, os.Stderr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	n.SetProgress(reportProgress)
+	if *leagueFile != "" {
+		p, err := usta.NewFileProvider(*leagueFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		n.SetProvider(p)
+	}
 
-	if _, statErr := os.Stat(dataFilePath); statErr != nil {
+	// Each organization has its own data file; only skip fetching if all
+	// of them are there.
+	var missing []string
+	for _, id := range c.OrganizationIDs {
+		path := formatters.OrganizationDataFilePath(dataFilePath, id, len(c.OrganizationIDs))
+		if _, statErr := os.Stat(path); statErr != nil {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) > 0 {
 		// No data file found — fetch live from USTA.
-		if err := n.Generate(ctx); err != nil {
-			fmt.Println(err)
-			return
+		err := n.Generate(ctx)
+		finishProgress()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 	} else {
-		slog.Info("data file found, will load instead of fetching from USTA", "path", dataFilePath)
+		finishProgress()
+		slog.Info("data files found, will load instead of fetching from USTA", "path", dataFilePath)
 	}
 
-	data, err := formatters.Prepare(n, fmtCfg)
+	sections, err := formatters.PrepareAll(n.Snapshot(), fmtCfg)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	live := slices.ContainsFunc(sections, func(d *formatters.PreparedData) bool { return d.DataFile == nil })
+	if history != nil && live {
+		if err := history.Save(); err != nil {
+			slog.Warn("could not save schedule state", "path", history.Path(), "error", err)
+		}
+	}
 
+	newsletters := sections
+	if c.Layout == internal.LayoutCombined && len(sections) > 1 {
+		newsletters = []*formatters.PreparedData{formatters.NewCombined(sections)}
+	}
+	for _, data := range newsletters {
+		if err := writeNewsletter(c, data, fmtCfg); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	slog.Info("done")
+}
+
+// writeNewsletter formats one newsletter with the configured formatters and
+// saves its data file.
+func writeNewsletter(c internal.Config, data *formatters.PreparedData, fmtCfg formatters.Config) error {
 	if err := c.RecentFormatter.FormatRecent(data, fmtCfg); err != nil {
-		fmt.Println(err)
-		return
+		return err
 	}
 
-	if err := c.UpcomingFormatter.FormatUpcoming(data, fmtCfg); err != nil {
-		fmt.Println(err)
-		return
+	if sf, ok := c.RecentFormatter.(formatters.StandingsFormatter); ok {
+		if err := sf.FormatStandings(data, fmtCfg); err != nil {
+			return err
+		}
 	}
 
-	if err := data.Save(); err != nil {
-		fmt.Println(err)
-		return
+	if sf, ok := c.RecentFormatter.(formatters.SeasonFormatter); ok {
+		if err := sf.FormatSeason(data, fmtCfg); err != nil {
+			return err
+		}
 	}
 
-	slog.Info("done")
+	if err := c.UpcomingFormatter.FormatUpcoming(data, fmtCfg); err != nil {
+		return err
+	}
+
+	return data.Save()
+}
+
+// joinIDs returns ids as a comma-separated list.
+func joinIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal"
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/usta"
+)
+
+// runOrgsCommand handles the "orgs" sub-command.
+func runOrgsCommand(args []string) error {
+	c := internal.DefaultConfig()
+
+	fs := flag.NewFlagSet("orgs", flag.ExitOnError)
+	cacheDir := fs.String("cache-dir", c.CacheDir, "directory for cached USTA pages")
+	noCache := fs.Bool("no-cache", false, "disable the on-disk page cache")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: usta-norcal-club-newsletter orgs [flags] search <text>\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 || fs.Arg(0) != "search" {
+		fs.Usage()
+		return fmt.Errorf("expected 'search <text>'")
+	}
+	query := strings.Join(fs.Args()[1:], " ")
+
+	if !*noCache {
+		usta.SetDiskCache(usta.NewDiskCache(*cacheDir, c.CacheTTLs))
+	}
+
+	orgs, err := usta.LoadOrganizationList(context.Background())
+	if err != nil {
+		return fmt.Errorf("loading organization list: %w", err)
+	}
+
+	matches := usta.SearchOrganizations(orgs, query)
+	if len(matches) == 0 {
+		fmt.Printf("No organizations match %q.\n", query)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tName\tTeams\n")
+	for _, o := range matches {
+		fmt.Fprintf(w, "%d\t%s\t%d\n", o.ID, o.Name, o.Teams)
+	}
+	return w.Flush()
+}
+
+// resolveOrganizationIDs returns the organization IDs for the -org flag, a
+// comma-separated list of organizations; see resolveOrganizationID.
+func resolveOrganizationIDs(ctx context.Context, value string) ([]int, error) {
+	var ids []int
+	for _, v := range strings.Split(value, ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		id, err := resolveOrganizationID(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no organization given")
+	}
+	return ids, nil
+}
+
+// resolveOrganizationID returns the organization ID for one organization in
+// the -org flag, which is either an ID or a name. A name resolves to the
+// organization with exactly that name, or else to the only one it matches.
+func resolveOrganizationID(ctx context.Context, value string) (int, error) {
+	if id, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+		return id, nil
+	}
+
+	orgs, err := usta.LoadOrganizationList(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("resolving organization %q: %w", value, err)
+	}
+
+	org, err := usta.ResolveOrganization(orgs, value)
+	if err != nil {
+		return 0, err
+	}
+	return org.ID, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ycombinator/usta-norcal-club-newsletter/internal/core"
+)
+
+// progressInterval is the least time between two progress reports within a
+// phase, so a fast run doesn't flood the terminal.
+const progressInterval = 100 * time.Millisecond
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 24
+
+// newProgressReporter returns the progress function for the -progress flag,
+// writing to f: "bar" draws a progress bar, "json" writes each report as a
+// line of JSON, "none" reports nothing and "auto" draws a bar if f is a
+// terminal. The returned finish function ends the output once Generate
+// returns.
+func newProgressReporter(mode string, f *os.File) (report core.ProgressFunc, finish func(), err error) {
+	if mode == "auto" {
+		mode = "none"
+		if isTerminal(f) {
+			mode = "bar"
+		}
+	}
+
+	switch mode {
+	case "none":
+		return nil, func() {}, nil
+	case "json":
+		enc := json.NewEncoder(f)
+		return throttleProgress(func(p core.Progress) { enc.Encode(p) }), func() {}, nil
+	case "bar":
+		// The bar takes the place of the info log, which would break it up.
+		// Warnings still go through, on their own lines above the bar.
+		oldLevel := slog.SetLogLoggerLevel(slog.LevelWarn)
+		oldOutput := log.Writer()
+		bar := &barWriter{f: f}
+		log.SetOutput(bar)
+		report = throttleProgress(bar.draw)
+		finish = func() {
+			bar.finish()
+			log.SetOutput(oldOutput)
+			slog.SetLogLoggerLevel(oldLevel)
+		}
+		return report, finish, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid -progress %q: use auto, bar, json or none", mode)
+	}
+}
+
+// barWriter draws the progress bar on the last line of f, and takes log
+// output, writing it above the bar.
+type barWriter struct {
+	f *os.File
+
+	mu sync.Mutex
+	// line is the bar as last drawn, or "" if it isn't shown.
+	line string
+}
+
+func (w *barWriter) draw(p core.Progress) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.line = progressBar(p)
+	fmt.Fprint(w.f, "\r\033[K"+w.line)
+}
+
+// Write clears the bar, writes the log output in its place and draws the
+// bar again below it.
+func (w *barWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.line != "" {
+		fmt.Fprint(w.f, "\r\033[K")
+	}
+	n, err := w.f.Write(b)
+	if w.line != "" {
+		fmt.Fprint(w.f, w.line)
+	}
+	return n, err
+}
+
+// finish leaves the bar as last drawn and moves past it.
+func (w *barWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.line != "" {
+		fmt.Fprintln(w.f)
+		w.line = ""
+	}
+}
+
+// throttleProgress passes on a report when the phase changes, the phase's
+// teams are all loaded, or progressInterval has passed since the last one.
+func throttleProgress(fn core.ProgressFunc) core.ProgressFunc {
+	var last core.Progress
+	var lastAt time.Time
+	return func(p core.Progress) {
+		now := time.Now()
+		if p.Phase == last.Phase && p.TeamsLoaded < p.TeamsTotal && now.Sub(lastAt) < progressInterval {
+			return
+		}
+		last, lastAt = p, now
+		fn(p)
+	}
+}
+
+// progressBar returns a one-line progress bar for p, e.g.
+// "matches       [######..................] 12/30, 45 pages, 120 cached, 1 error".
+func progressBar(p core.Progress) string {
+	filled := progressBarWidth
+	if p.TeamsTotal > 0 {
+		filled = progressBarWidth * p.TeamsLoaded / p.TeamsTotal
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", progressBarWidth-filled)
+
+	s := fmt.Sprintf("%-13s [%s] %d/%d, %d pages, %d cached", p.Phase, bar, p.TeamsLoaded, p.TeamsTotal, p.PagesFetched, p.CacheHits)
+	switch p.Errors {
+	case 0:
+	case 1:
+		s += ", 1 error"
+	default:
+		s += fmt.Sprintf(", %d errors", p.Errors)
+	}
+	return s
+}
+
+// isTerminal reports whether w is a terminal rather than a file or pipe.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}